@@ -0,0 +1,246 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(allCmd)
+	allCmd.Flags().IntP("jobs", "j", runtime.NumCPU(), "How many solutions to run at once. Allocations are only shown with 1")
+}
+
+var allCmd = &cobra.Command{
+	Use:   "all",
+	Short: "Run all solutions",
+	Long:  "Run every part of every day against its input and show a table of the results",
+	RunE:  allMain,
+}
+
+type RunResult struct {
+	Day       int
+	Part      int
+	InputFile string
+	InputHash string
+	Answer    any
+	Extras    map[string]any
+	Err       error
+	Skipped   bool
+	Elapsed   time.Duration
+	Timing    Timing
+	Measured  bool
+	Allocs    uint64
+	Bytes     uint64
+}
+
+func (r RunResult) Failed() bool {
+	return r.Err != nil && !r.Skipped
+}
+
+// Allocation counts come from the global memory stats, so they are only exact with one job at a time. Measured
+// says whether they can be trusted
+func RunSolver(ctx context.Context, s Solver, part int, inputStr string, inputFile string) (res RunResult) {
+	res.Day = s.Day()
+	res.Part = part
+	res.InputFile = inputFile
+	res.InputHash = hashInput(inputStr)
+	ctx, extras := withExtras(ctx)
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+
+	defer func() {
+		res.Elapsed = time.Since(start)
+		runtime.ReadMemStats(&after)
+		res.Measured = true
+		res.Allocs = after.Mallocs - before.Mallocs
+		res.Bytes = after.TotalAlloc - before.TotalAlloc
+		res.Extras = extras.Map()
+
+		if r := recover(); r != nil {
+			logger.Debug("Solver panicked:", "day", res.Day, "part", part, "stack", string(debug.Stack()))
+			res.Answer = nil
+			res.Err = fmt.Errorf("Solver panicked: %v", r)
+		}
+	}()
+
+	res.Answer, res.Timing, res.Err = SolveTimed(ctx, s, part, inputStr, inputFile)
+	return
+}
+
+type RunJob struct {
+	Solver    Solver
+	Part      int
+	InputFile string
+}
+
+// The timeout applies to each job on its own so one hung day doesn't starve the rest
+func runJob(ctx context.Context, j RunJob, timeout time.Duration) RunResult {
+	_, err := os.Stat(j.InputFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return RunResult{Day: j.Solver.Day(), Part: j.Part, InputFile: j.InputFile, Err: err, Skipped: true}
+	}
+
+	inputStr, err := readInput(j.InputFile)
+	if err != nil {
+		return RunResult{Day: j.Solver.Day(), Part: j.Part, InputFile: j.InputFile, Err: err}
+	}
+
+	ctx, cancel := withTimeout(ctx, timeout)
+	defer cancel()
+
+	return RunSolver(ctx, j.Solver, j.Part, inputStr, j.InputFile)
+}
+
+func RunJobs(ctx context.Context, jobs []RunJob, workers int, timeout time.Duration) []RunResult {
+	results := make([]RunResult, len(jobs))
+	queue := make(chan int)
+
+	var wg sync.WaitGroup
+	for range max(workers, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range queue {
+				results[i] = runJob(ctx, jobs[i], timeout)
+
+				// Other workers allocate at the same time, so the counts would include theirs
+				if workers > 1 {
+					results[i].Measured = false
+					results[i].Allocs = 0
+					results[i].Bytes = 0
+				}
+			}
+		}()
+	}
+
+	for i := range jobs {
+		queue <- i
+	}
+	close(queue)
+	wg.Wait()
+
+	return results
+}
+
+func RunAll(ctx context.Context, dir string, workers int, timeout time.Duration) []RunResult {
+	jobs := make([]RunJob, 0)
+	for _, s := range Solvers() {
+		inputFile := filepath.Join(dir, DayName(s.Day()) + ".txt")
+		for _, part := range []int{1, 2} {
+			jobs = append(jobs, RunJob{Solver: s, Part: part, InputFile: inputFile})
+		}
+	}
+	return RunJobs(ctx, jobs, workers, timeout)
+}
+
+func formatAnswer(answer any) string {
+	ans := fmt.Sprintf("%v", answer)
+	if strings.Contains(ans, "\n") {
+		return fmt.Sprintf("(%v lines)", strings.Count(strings.TrimSpace(ans), "\n") + 1)
+	}
+	return ans
+}
+
+func allMain(cmd *cobra.Command, args []string) error {
+	jobs, err := cmd.Flags().GetInt("jobs")
+	if err != nil {
+		return &FlagError{Flag: "jobs", Err: err}
+	}
+
+	timeout, err := getTimeout(cmd)
+	if err != nil {
+		return err
+	}
+
+	format, err := getFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	inputsDir, err := getInputsDir(cmd)
+	if err != nil {
+		return err
+	}
+
+	results := RunAll(cmd.Context(), inputsDir, jobs, timeout)
+
+	if format == FORMAT_JSON {
+		rjs := make([]ResultJSON, len(results))
+		for i, r := range results {
+			rjs[i] = r.JSON()
+		}
+		err = writeJSON(os.Stdout, rjs)
+		if err != nil {
+			return err
+		}
+	} else {
+		printResults(results)
+	}
+
+	return RunErr(results, inputsDir)
+}
+
+// Skipped runs aren't failures, but if every run was skipped the inputs directory is wrong and nothing was
+// checked, so that is an error too
+func RunErr(results []RunResult, dir string) error {
+	failures, ran := 0, 0
+	for _, r := range results {
+		if r.Failed() {
+			failures++
+		}
+		if !r.Skipped {
+			ran++
+		}
+	}
+
+	if ran == 0 {
+		return &InputError{Path: dir, Err: fmt.Errorf("No inputs found, every run was skipped")}
+	} else if failures > 0 {
+		return fmt.Errorf("%v of %v runs failed", failures, len(results))
+	}
+	return nil
+}
+
+func printResults(results []RunResult) {
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "DAY\tPART\tANSWER\tTIME\tALLOCS\tBYTES\t")
+
+	for _, r := range results {
+		ans := formatAnswer(r.Answer)
+		if r.Skipped {
+			ans = "skipped (no input)"
+		} else if r.Err != nil {
+			ans = "ERROR"
+		}
+		allocs, bytes := "-", "-"
+		if r.Measured {
+			allocs, bytes = fmt.Sprint(r.Allocs), fmt.Sprint(r.Bytes)
+		}
+		fmt.Fprintf(
+			tw,
+			"%v\t%v\t%v\t%v\t%v\t%v\t\n",
+			r.Day, r.Part, ans, r.Elapsed.Round(time.Microsecond), allocs, bytes,
+		)
+	}
+	tw.Flush()
+
+	for _, r := range results {
+		if r.Failed() {
+			fmt.Fprintf(os.Stderr, "%v %v: %v\n", DayName(r.Day), PartName(r.Part), r.Err)
+		}
+	}
+}
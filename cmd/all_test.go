@@ -0,0 +1,138 @@
+package cmd_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dusktreader/advent-of-code-2024/cmd"
+	"github.com/dusktreader/advent-of-code-2024/util"
+)
+
+type panicSolver struct{}
+
+func (s panicSolver) Day() int                                         { return 99 }
+func (s panicSolver) Parse(inputStr string) (any, error)               { return inputStr, nil }
+func (s panicSolver) Part1(ctx context.Context, input any) (any, error) { return len(input.(string)), nil }
+func (s panicSolver) Part2(ctx context.Context, input any) (any, error) { panic("Oh no!") }
+
+type hangSolver struct{}
+
+func (s hangSolver) Day() int                           { return 98 }
+func (s hangSolver) Parse(inputStr string) (any, error) { return inputStr, nil }
+func (s hangSolver) Part1(ctx context.Context, input any) (any, error) {
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+}
+func (s hangSolver) Part2(ctx context.Context, input any) (any, error) { return s.Part1(ctx, input) }
+
+func TestRunSolver(t *testing.T) {
+	got := cmd.RunSolver(context.Background(), panicSolver{}, 1, "abc", "")
+	if got.Err != nil {
+		t.Fatalf("Unexpected error: %v", got.Err)
+	}
+	if got.Answer != 3 {
+		t.Errorf("Wrong answer: want 3, got %v", got.Answer)
+	}
+	if got.Day != 99 || got.Part != 1 {
+		t.Errorf("Wrong day or part: got %v, %v", got.Day, got.Part)
+	}
+}
+
+func TestRunSolverPanic(t *testing.T) {
+	got := cmd.RunSolver(context.Background(), panicSolver{}, 2, "abc", "")
+	if got.Err == nil {
+		t.Fatalf("Did not get error from panicking solver!")
+	}
+	if !got.Failed() {
+		t.Errorf("Panicking solver should count as a failure")
+	}
+}
+
+func TestRunSolverTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10 * time.Millisecond)
+	defer cancel()
+
+	got := cmd.RunSolver(ctx, hangSolver{}, 1, "abc", "")
+	var timeoutErr *cmd.TimeoutError
+	if !errors.As(got.Err, &timeoutErr) {
+		t.Fatalf("Hung solver should time out, got %#v", got.Err)
+	}
+	if cmd.ExitCode(got.Err) != cmd.EXIT_TIMEOUT {
+		t.Errorf("Wrong exit code for timeout: %v", cmd.ExitCode(got.Err))
+	}
+}
+
+func TestRunSolverExtras(t *testing.T) {
+	s, err := cmd.GetSolver(2)
+	util.Unexpect(t, err)
+
+	inputStr := "7 6 4 2 1\n1 2 7 8 9\n9 7 6 2 1\n1 3 2 4 5\n8 6 4 4 1\n1 3 6 7 9\n"
+	got := cmd.RunSolver(context.Background(), s, 2, inputStr, "")
+	util.Unexpect(t, got.Err)
+
+	want := map[string]any{"reports": 6, "safe": 2, "safe_dampened": 2, "unsafe": 2}
+	if !reflect.DeepEqual(want, got.Extras) {
+		t.Errorf("Wrong extras: want %v, got %v", want, got.Extras)
+	}
+
+	rj := got.JSON()
+	if rj.Answer != 4 || rj.InputHash == "" || rj.Error != "" {
+		t.Errorf("Bad JSON result: %+v", rj)
+	}
+}
+
+func TestRunAllMissingInputs(t *testing.T) {
+	results := cmd.RunAll(context.Background(), t.TempDir(), 2, 0)
+	if len(results) != len(cmd.Solvers()) * 2 {
+		t.Fatalf("Wrong result count: want %v, got %v", len(cmd.Solvers()) * 2, len(results))
+	}
+
+	for _, r := range results {
+		if !r.Skipped || r.Failed() {
+			t.Errorf("Run without input should be skipped: %+v", r)
+		}
+	}
+}
+
+func TestRunAllNothingRan(t *testing.T) {
+	dir := t.TempDir()
+	err := cmd.RunErr(cmd.RunAll(context.Background(), dir, 2, 0), dir)
+	if cmd.ExitCode(err) != cmd.EXIT_INPUT {
+		t.Errorf("Expected an input error when every run is skipped, got %v", err)
+	}
+
+	results := []cmd.RunResult{{Day: 1, Part: 1, Answer: 11}, {Day: 1, Part: 2, Skipped: true}}
+	err = cmd.RunErr(results, dir)
+	util.Unexpect(t, err)
+}
+
+func TestRunJobsMeasured(t *testing.T) {
+	inputFile := filepath.Join(t.TempDir(), "day99.txt")
+	err := os.WriteFile(inputFile, []byte("abc\n"), 0644)
+	util.Unexpect(t, err)
+	jobs := []cmd.RunJob{
+		{Solver: panicSolver{}, Part: 1, InputFile: inputFile},
+		{Solver: panicSolver{}, Part: 1, InputFile: inputFile},
+	}
+
+	for _, r := range cmd.RunJobs(context.Background(), jobs, 1, 0) {
+		if !r.Measured || r.JSON().Allocs == nil {
+			t.Errorf("Allocations should be measured with one worker: %+v", r)
+		}
+	}
+
+	// Other workers' allocations would be counted too, so none are reported
+	for _, r := range cmd.RunJobs(context.Background(), jobs, 2, 0) {
+		if r.Measured || r.Allocs != 0 || r.JSON().Allocs != nil {
+			t.Errorf("Allocations shouldn't be reported with several workers: %+v", r)
+		}
+	}
+}
@@ -1,99 +1,82 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
-	"log/slog"
 	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/dusktreader/advent-of-code-2024/util"
-	"github.com/spf13/cobra"
 )
 
 func init() {
-	rootCmd.AddCommand(d1Cmd)
-	d1Cmd.PersistentFlags().StringP("input-file", "i", "", "Get input from a file instead of stdin")
-	d1Cmd.AddCommand(d1p1Cmd)
-	d1Cmd.AddCommand(d1p2Cmd)
+	Register(&d1Solver{})
 }
 
-var d1Cmd = &cobra.Command{
-	Use:   "day01",
-	Short: "Day 1 Solutions",
-	Long:  "The solutions for day 1 of Advent of Code 2024",
-	Run:   d1Main,
-}
+type d1Solver struct{}
 
-var d1p1Cmd = &cobra.Command{
-	Use: "part1",
-	Short: "Day 1, 1 Solution",
-	Long:  "The solution for day 1, part 1 of Advent of Code 2024",
-	Run:   d1p1Main,
+func (d *d1Solver) Day() int {
+	return 1
 }
 
-var d1p2Cmd = &cobra.Command{
-	Use: "part2",
-	Short: "Day 1, 2 Solution",
-	Long:  "The solution for day 1, part 2 of Advent of Code 2024",
-	Run:   d1p2Main,
+func (d *d1Solver) Parse(inputStr string) (any, error) {
+	left, right, err := ParseLocations(inputStr)
+	if err != nil {
+		return nil, err
+	}
+	return util.MakePair(left, right), nil
 }
 
-func d1Main(cmd *cobra.Command, args []string){
-	_ = cmd.Help()
+func (d *d1Solver) Part1(ctx context.Context, input any) (any, error) {
+	lists := input.(util.Pair[[]int])
+
+	distance, err := TotalDistance(lists.Left, lists.Right)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Debug("Results:", "distance", distance, "left", lists.Left, "right", lists.Right)
+	return distance, nil
 }
 
-func d1GetInput(cmd *cobra.Command, args []string) (left []int, right []int, err error) {
-	inputStr, err := loadInput(cmd, args)
+func (d *d1Solver) Part2(ctx context.Context, input any) (any, error) {
+	lists := input.(util.Pair[[]int])
+
+	similarity, err := Similarity(lists.Left, lists.Right)
 	if err != nil {
-		return left, right, fmt.Errorf("Couldn't load input: %#v", err)
+		return nil, err
 	}
 
+	logger.Debug("Results:", "similarity", similarity, "left", lists.Left, "right", lists.Right)
+	return similarity, nil
+}
+
+func ParseLocations(inputStr string) (left []int, right []int, err error) {
 	inputStr = strings.TrimSpace(inputStr)
-	inputStr = strings.ReplaceAll(inputStr, "\n", " ")
+	lines := strings.Split(inputStr, "\n")
 
-	isLeft := true
+	for i, line := range lines {
+		line = strings.TrimRight(line, "\r")
+		tokens, offsets := fieldsAt(line)
+		if len(tokens) != 2 {
+			return left, right, parseErr(i, -1, line, "Expected 2 location ids, found %v", len(tokens))
+		}
 
-	tokens := strings.Split(inputStr, " ")
-	for _, token := range tokens {
-		if token != "" {
+		numbers := [2]int{}
+		for k, token := range tokens {
 			number, err := strconv.Atoi(token)
 			if err != nil {
-				return left, right, fmt.Errorf("Failed to convert a token: %#v", err)
-			}
-			if isLeft {
-				left = append(left, number)
-			} else {
-				right = append(right, number)
+				return left, right, parseErr(i, offsets[k], line, "Location id %q is not a number", token)
 			}
-			isLeft = !isLeft
+			numbers[k] = number
 		}
+		left = append(left, numbers[0])
+		right = append(right, numbers[1])
 	}
 	return left, right, nil
 }
 
-func d1p1Main(cmd *cobra.Command, args []string){
-	left, right, err := d1GetInput(cmd, args)
-	MaybeDie(err)
-
-	distance, err := TotalDistance(left, right)
-	MaybeDie(err)
-
-	slog.Debug("Results:", "distance", distance, "left", left, "right", right)
-	fmt.Printf("%v\n", distance)
-}
-
-func d1p2Main(cmd *cobra.Command, args []string){
-	left, right, err := d1GetInput(cmd, args)
-	MaybeDie(err)
-
-	similarity, err := Similarity(left, right)
-	MaybeDie(err)
-
-	slog.Debug("Results:", "similarity", similarity, "left", left, "right", right)
-	fmt.Printf("%v\n", similarity)
-}
-
 func TotalDistance(left []int, right []int) (int, error) {
 	if len(left) != len(right) {
 		return 0, fmt.Errorf("Need an even number of number inputs")
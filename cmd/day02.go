@@ -1,92 +1,71 @@
 package cmd
 
 import (
-	"fmt"
-	"log/slog"
+	"context"
 	"strconv"
 	"strings"
 
 	"github.com/dusktreader/advent-of-code-2024/util"
-	"github.com/spf13/cobra"
 )
 
 func init() {
-	rootCmd.AddCommand(d2Cmd)
-	d2Cmd.PersistentFlags().StringP("input-file", "i", "", "Get input from a file instead of stdin")
-	d2Cmd.AddCommand(d2p1Cmd)
-	d2Cmd.AddCommand(d2p2Cmd)
+	Register(&d2Solver{})
 }
 
-var d2Cmd = &cobra.Command{
-	Use:   "day02",
-	Short: "Day 2 Solutions",
-	Long:  "The solutions for day 2 of Advent of Code 2024",
-	Run:   d2Main,
-}
-
-var d2p1Cmd = &cobra.Command{
-	Use:   "part1",
-	Short: "Day 2, 1 Solution",
-	Long:  "The solution for day 2, part 1 of Advent of Code 2024",
-	Run:   d2p1Main,
-}
+type d2Solver struct{}
 
-var d2p2Cmd = &cobra.Command{
-	Use:   "part2",
-	Short: "Day 2, 2 Solution",
-	Long:  "The solution for day 2, part 2 of Advent of Code 2024",
-	Run:   d2p2Main,
+func (d *d2Solver) Day() int {
+	return 2
 }
 
-func d2Main(cmd *cobra.Command, args []string){
-	_ = cmd.Help()
+func (d *d2Solver) Parse(inputStr string) (any, error) {
+	return ParseReport(inputStr)
 }
 
-func d2p1Main(cmd *cobra.Command, args []string) {
-	inputStr, err := loadInput(cmd, args)
-	MaybeDie(err)
-
-	reports, err := ParseReport(inputStr)
-	MaybeDie(err)
+func (d *d2Solver) Part1(ctx context.Context, input any) (any, error) {
+	reports := input.([][]int)
 
 	safeCount := CountSafe(reports, 3, 0)
+	AddExtra(ctx, "reports", len(reports))
+	AddExtra(ctx, "safe", safeCount)
+	AddExtra(ctx, "unsafe", len(reports) - safeCount)
 
-	slog.Debug("Results (undampened):", "safeCount", safeCount)
-	fmt.Printf("%v\n", safeCount)
+	logger.Debug("Results (undampened):", "safeCount", safeCount)
+	return safeCount, nil
 }
 
-func d2p2Main(cmd *cobra.Command, args []string) {
-	inputStr, err := loadInput(cmd, args)
-	MaybeDie(err)
-
-	reports, err := ParseReport(inputStr)
-	MaybeDie(err)
+func (d *d2Solver) Part2(ctx context.Context, input any) (any, error) {
+	reports := input.([][]int)
 
 	safeCount := CountSafe(reports, 3, 1)
-
-	slog.Debug("Results (dampened):", "safeCount", safeCount)
-	fmt.Printf("%v\n", safeCount)
+	undampened := CountSafe(reports, 3, 0)
+	AddExtra(ctx, "reports", len(reports))
+	AddExtra(ctx, "safe", undampened)
+	AddExtra(ctx, "safe_dampened", safeCount - undampened)
+	AddExtra(ctx, "unsafe", len(reports) - safeCount)
+
+	logger.Debug("Results (dampened):", "safeCount", safeCount)
+	return safeCount, nil
 }
 
 func ParseReport(inputStr string) (reports [][]int, err error) {
+	inputStr = strings.TrimSpace(inputStr)
 	lines := strings.Split(inputStr, "\n")
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
-			slog.Debug("Skipping empty line")
+	for i, line := range lines {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			logger.Debug("Skipping empty line")
 			continue
 		}
 
-		tokens := strings.Split(line, " ")
+		tokens, offsets := fieldsAt(line)
 		var numbers []int
-		for _, token := range tokens {
-			if token != "" {
-				number, err := strconv.Atoi(token)
-				if err != nil {
-					return reports, fmt.Errorf("Failed to convert a token: %#v", err)
-				}
-				numbers = append(numbers, number)
+		for k, token := range tokens {
+			number, err := strconv.Atoi(token)
+			if err != nil {
+				return reports, parseErr(i, offsets[k], line, "Failed to convert a token: %q is not a number", token)
 			}
+			numbers = append(numbers, number)
 		}
 		reports = append(reports, make([]int, len(numbers)))
 		copy(reports[len(reports) - 1], numbers)
@@ -101,8 +80,8 @@ type JLimit struct {
 }
 
 func IsSafe(report []int, diffMax int, dampMax int) (safe bool) {
-	slog.Debug("-----------------------------")
-	slog.Debug("Checking report:", "report", report, "diffMax", diffMax, "dampMax", dampMax)
+	logger.Debug("-----------------------------")
+	logger.Debug("Checking report:", "report", report, "diffMax", diffMax, "dampMax", dampMax)
 
 	var dir int
 	var diff int
@@ -123,9 +102,9 @@ func IsSafe(report []int, diffMax int, dampMax int) (safe bool) {
 	for _, lim := range limits {
 
 		if lim.incr == 1 {
-			slog.Debug("Sweeping forward--->")
+			logger.Debug("Sweeping forward--->")
 		} else {
-			slog.Debug("<---Sweeping backward")
+			logger.Debug("<---Sweeping backward")
 		}
 
 		dir = 0
@@ -137,27 +116,27 @@ func IsSafe(report []int, diffMax int, dampMax int) (safe bool) {
 			absDiff = util.AbsInt(diff)
 			if dir == 0 && absDiff > 0 {
 				dir = diff / absDiff
-				slog.Debug("Direction is:", "dir", dir)
+				logger.Debug("Direction is:", "dir", dir)
 			}
-			slog.Debug("Checking index:", "j", j, "diff", diff)
+			logger.Debug("Checking index:", "j", j, "diff", diff)
 
 			if absDiff < 1 || absDiff > diffMax {
 				if dampCount < dampMax {
-					slog.Debug("Dampened (diff) at:", "j", j)
+					logger.Debug("Dampened (diff) at:", "j", j)
 					dampCount++
 					continue
 				} else {
-					slog.Debug("Unsafe (diff) at:", "j", j)
+					logger.Debug("Unsafe (diff) at:", "j", j)
 					safe = false
 					break
 				}
 			} else if dir != diff / absDiff {
 				if dampCount < dampMax {
-					slog.Debug("Dampened (dir) at:", "j", j)
+					logger.Debug("Dampened (dir) at:", "j", j)
 					dampCount++
 					continue
 				} else {
-					slog.Debug("Unsafe (dir) at:", "j", j)
+					logger.Debug("Unsafe (dir) at:", "j", j)
 					safe = false
 					break
 				}
@@ -165,11 +144,11 @@ func IsSafe(report []int, diffMax int, dampMax int) (safe bool) {
 			lastValue = report[j]
 		}
 		if safe {
-			slog.Debug("Report was safe")
+			logger.Debug("Report was safe")
 			return
 		}
 	}
-	slog.Debug("Report was unsafe")
+	logger.Debug("Report was unsafe")
 	return false
 }
 
@@ -178,7 +157,7 @@ func CountSafe(reports [][]int, diffMax int, dampMax int) (safeCount int) {
 		return 0
 	}
 
-	slog.Debug("Counting safe:", "diffMax", diffMax, "dampMax", dampMax)
+	logger.Debug("Counting safe:", "diffMax", diffMax, "dampMax", dampMax)
 	for _, report := range reports {
 		if IsSafe(report, diffMax, dampMax) {
 			safeCount++
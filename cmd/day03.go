@@ -1,8 +1,7 @@
 package cmd
 
 import (
-	"fmt"
-	"log/slog"
+	"context"
 	"regexp"
 	"strconv"
 	"strings"
@@ -12,62 +11,46 @@ import (
 )
 
 func init() {
-	rootCmd.AddCommand(d3Cmd)
-	d3Cmd.PersistentFlags().StringP("input-file", "i", "", "Get input from a file instead of stdin")
-	d3Cmd.AddCommand(d3p1Cmd)
-	d3Cmd.AddCommand(d3p2Cmd)
-	d3p2Cmd.Flags().BoolP("annotate", "a", false, "Annotate redacted input string")
+	Register(&d3Solver{})
 }
 
-var d3Cmd = &cobra.Command{
-	Use:   "day03",
-	Short: "Day 3 Solutions",
-	Long:  "The solutions for day 3 of Advent of Code 2024",
-	Run:   d3Main,
+type d3Solver struct {
+	annotate bool
 }
 
-var d3p1Cmd = &cobra.Command{
-	Use:   "part1",
-	Short: "Day 3, 1 Solution",
-	Long:  "The solution for day 3, part 1 of Advent of Code 2024",
-	Run:   d3p1Main,
+func (d *d3Solver) Day() int {
+	return 3
 }
 
-var d3p2Cmd = &cobra.Command{
-	Use:   "part2",
-	Short: "Day 3, 2 Solution",
-	Long:  "The solution for day 3, part 2 of Advent of Code 2024",
-	Run:   d3p2Main,
+func (d *d3Solver) Flags(part int, cmd *cobra.Command) {
+	if part == 2 {
+		cmd.Flags().BoolVarP(&d.annotate, "annotate", "a", false, "Annotate redacted input string")
+	}
 }
 
-func d3Main(cmd *cobra.Command, args []string){
-	_ = cmd.Help()
+func (d *d3Solver) Parse(inputStr string) (any, error) {
+	return inputStr, nil
 }
 
-func d3p1Main(cmd *cobra.Command, args []string) {
-	inputStr, err := loadInput(cmd, args)
-	MaybeDie(err)
+func (d *d3Solver) Part1(ctx context.Context, input any) (any, error) {
+	inputStr := input.(string)
 
 	instructions := IsolatePairs(inputStr)
 	total := ProcessPairs(instructions)
 
-	slog.Debug("Results:", "total", total)
-	fmt.Printf("%v\n", total)
+	logger.Debug("Results:", "total", total)
+	return total, nil
 }
 
-func d3p2Main(cmd *cobra.Command, args []string) {
-	annotate, err := cmd.Flags().GetBool("annotate")
-	MaybeDie(err)
-
-	inputStr, err := loadInput(cmd, args)
-	MaybeDie(err)
+func (d *d3Solver) Part2(ctx context.Context, input any) (any, error) {
+	inputStr := input.(string)
 
-	inputStr = Redact(inputStr, annotate)
+	inputStr = Redact(inputStr, d.annotate)
 	instructions := IsolatePairs(inputStr)
 	total := ProcessPairs(instructions)
 
-	slog.Debug("Results:", "total", total)
-	fmt.Printf("%v\n", total)
+	logger.Debug("Results:", "total", total)
+	return total, nil
 }
 
 func Redact(inputStr string, annotate bool) (outputStr string) {
@@ -77,7 +60,7 @@ func Redact(inputStr string, annotate bool) (outputStr string) {
 	}
 	re := regexp.MustCompile(`(?s)don't\(\).*?(?:do\(\)|$)`)
 	outputStr = string(re.ReplaceAll([]byte(inputStr), []byte(repl)))
-	slog.Debug("Redacted input:", "outputStr", outputStr)
+	logger.Debug("Redacted input:", "outputStr", outputStr)
 	return
 
 }
@@ -91,13 +74,13 @@ func IsolatePairs(inputStr string) (pairs []util.Pair[int]) {
 	for _, match := range matches {
 		left, err := strconv.Atoi(string(match[1]))
 		if err != nil {
-			slog.Debug("Skipping match due to failed integer conversion in left operand", "err", err)
+			logger.Debug("Skipping match due to failed integer conversion in left operand", "err", err)
 			continue
 		}
 
 		right, err := strconv.Atoi(string(match[2]))
 		if err != nil {
-			slog.Debug("Skipping match due to failed integer conversion in right operand", "err", err)
+			logger.Debug("Skipping match due to failed integer conversion in right operand", "err", err)
 			continue
 		}
 		pairs = append(pairs, util.Pair[int]{left, right})
@@ -107,11 +90,11 @@ func IsolatePairs(inputStr string) (pairs []util.Pair[int]) {
 
 func ProcessPairs(pairs []util.Pair[int]) (total int) {
 	for i, pair := range pairs {
-		slog.Debug("Processing pair:", "i", i, "pair", pair)
+		logger.Debug("Processing pair:", "i", i, "pair", pair)
 		val := pair.Left * pair.Right
-		slog.Debug("Value is:", "val", val)
+		logger.Debug("Value is:", "val", val)
 		total += val
-		slog.Debug("New total is:", "total", total)
+		logger.Debug("New total is:", "total", total)
 	}
 	return
 }
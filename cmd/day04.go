@@ -1,72 +1,42 @@
 package cmd
 
 import (
-	"fmt"
-	"log/slog"
+	"context"
 	"math/rand/v2"
 	"slices"
 	"strings"
-
-	"github.com/spf13/cobra"
 )
 
 func init() {
-	rootCmd.AddCommand(d4Cmd)
-	d4Cmd.PersistentFlags().StringP("input-file", "i", "", "Get input from a file instead of stdin")
-	d4Cmd.AddCommand(d4p1Cmd)
-	d4Cmd.AddCommand(d4p2Cmd)
-	d4p2Cmd.Flags().BoolP("annotate", "a", false, "Annotate redacted input string")
-}
-
-var d4Cmd = &cobra.Command{
-	Use:   "day04",
-	Short: "Day 4 Solutions",
-	Long:  "The solutions for day 4 of Advent of Code 2024",
-	Run:   d4Main,
+	Register(&d4Solver{})
 }
 
-var d4p1Cmd = &cobra.Command{
-	Use:   "part1",
-	Short: "Day 3, 1 Solution",
-	Long:  "The solution for day 4, part 1 of Advent of Code 2024",
-	Run:   d4p1Main,
-}
+type d4Solver struct{}
 
-var d4p2Cmd = &cobra.Command{
-	Use:   "part2",
-	Short: "Day 3, 2 Solution",
-	Long:  "The solution for day 4, part 2 of Advent of Code 2024",
-	Run:   d4p2Main,
+func (d *d4Solver) Day() int {
+	return 4
 }
 
-func d4Main(cmd *cobra.Command, args []string){
-	_ = cmd.Help()
+func (d *d4Solver) Parse(inputStr string) (any, error) {
+	return Runify(inputStr)
 }
 
-func d4p1Main(cmd *cobra.Command, args []string) {
-	inputStr, err := loadInput(cmd, args)
-	MaybeDie(err)
-
-	board, err := Runify(inputStr)
-	MaybeDie(err)
+func (d *d4Solver) Part1(ctx context.Context, input any) (any, error) {
+	board := input.([][]rune)
 
 	count := CountMatches([]rune("XMAS"), board)
 
-	slog.Debug("Results:", "count", count)
-	fmt.Printf("%v\n", count)
+	logger.Debug("Results:", "count", count)
+	return count, nil
 }
 
-func d4p2Main(cmd *cobra.Command, args []string) {
-	inputStr, err := loadInput(cmd, args)
-	MaybeDie(err)
-
-	board, err := Runify(inputStr)
-	MaybeDie(err)
+func (d *d4Solver) Part2(ctx context.Context, input any) (any, error) {
+	board := input.([][]rune)
 
 	count := CountCrossWords([]rune("MAS"), board)
 
-	slog.Debug("Results:", "count", count)
-	fmt.Printf("%v\n", count)
+	logger.Debug("Results:", "count", count)
+	return count, nil
 }
 
 func Runify(inputStr string) (runes [][]rune, err error) {
@@ -76,11 +46,11 @@ func Runify(inputStr string) (runes [][]rune, err error) {
 	for i, line := range lines {
 		line = strings.TrimSpace(line)
 		if len(line) == 0 {
-			return nil, fmt.Errorf("Line #%v was empty", i)
+			return nil, parseErr(i, -1, line, "Line was empty")
 		} else if lineLen == 0 {
 			lineLen = len(line)
 		} else if lineLen != len(line) {
-			return nil, fmt.Errorf("Line #%v didn't match previous line lengths", i)
+			return nil, parseErr(i, min(lineLen, len(line)), line, "Line didn't match previous line lengths: expected %v letters, found %v", lineLen, len(line))
 		}
 		runes = append(runes, []rune(line))
 	}
@@ -124,26 +94,26 @@ func CountMatches(word []rune, board [][]rune) (count int) {
 	for i := 0; i < len(board); i++ {
 		for j := 0; j < len(board[0]); j++ {
 			pt := Point{i, j}
-			slog.Debug("-------------")
-			slog.Debug("Checking at point:", "i", pt.I, "j", pt.J)
+			logger.Debug("-------------")
+			logger.Debug("Checking at point:", "i", pt.I, "j", pt.J)
 			for _, v := range vectors {
-				slog.Debug("Checking with vector:", "di", v.I, "dj", v.J)
+				logger.Debug("Checking with vector:", "di", v.I, "dj", v.J)
 				for m := l - 1; m >= 0; m-- {
 					off := add(pt, mul(v, m))
-					slog.Debug("Checking at offset:", "i", off.I, "j", off.J)
+					logger.Debug("Checking at offset:", "i", off.I, "j", off.J)
 					if m == l - 1 && !inBounds(board, off) {
-						slog.Debug("Out of bounds!")
+						logger.Debug("Out of bounds!")
 						break
 					} else if fetch(board, off) != word[m] {
-						slog.Debug("Doesn't match!")
+						logger.Debug("Doesn't match!")
 						break
 					} else if m == 0 {
-						slog.Debug("Count it!")
+						logger.Debug("Count it!")
 						count++
 					}
 				}
 			}
-			slog.Debug("-------------")
+			logger.Debug("-------------")
 		}
 	}
 	return
@@ -1,127 +1,128 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
-	"log/slog"
+	"slices"
 	"strconv"
 	"strings"
 
+	"github.com/dusktreader/advent-of-code-2024/graph"
 	"github.com/dusktreader/advent-of-code-2024/util"
 	"github.com/spf13/cobra"
 )
 
 func init() {
-	rootCmd.AddCommand(d5Cmd)
-	d5Cmd.PersistentFlags().StringP("input-file", "i", "", "Get input from a file instead of stdin")
-	d5Cmd.AddCommand(d5p1Cmd)
-	d5Cmd.AddCommand(d5p2Cmd)
-	d5p2Cmd.Flags().BoolP("annotate", "a", false, "Annotate redacted input string")
-	d5Cmd.AddCommand(mermaidCmd)
-	d5Cmd.AddCommand(dotCmd)
-	d5Cmd.AddCommand(validateCmd)
+	Register(&d5Solver{})
 }
 
-var d5Cmd = &cobra.Command{
-	Use:   "day05",
-	Short: "Day 5 Solutions",
-	Long:  "The solutions for day 5 of Advent of Code 2025",
-	Run:   d5Main,
+type d5Solver struct{}
+
+func (d *d5Solver) Day() int {
+	return 5
+}
+
+func (d *d5Solver) Commands() []*cobra.Command {
+	return []*cobra.Command{mermaidCmd, dotCmd, validateCmd}
 }
 
-var d5p1Cmd = &cobra.Command{
-	Use:   "part1",
-	Short: "Day 5, 1 Solution",
-	Long:  "The solution for day 5, part 1 of Advent of Code 2025",
-	Run:   d5p1Main,
+func (d *d5Solver) Parse(inputStr string) (any, error) {
+	return ParseInput(inputStr)
 }
 
-var d5p2Cmd = &cobra.Command{
-	Use:   "part2",
-	Short: "Day 5, 2 Solution",
-	Long:  "The solution for day 5, part 2 of Advent of Code 2025",
-	Run:   d5p2Main,
+func (d *d5Solver) Part1(ctx context.Context, input any) (any, error) {
+	manual := input.(Manual)
+
+	manual.Validate()
+	logger.Debug("Results:", "ValidCheckSum", manual.ValidCheckSum)
+	return manual.ValidCheckSum, nil
+}
+
+func (d *d5Solver) Part2(ctx context.Context, input any) (any, error) {
+	manual := input.(Manual)
+
+	manual.Validate()
+	manual.Amend()
+	logger.Debug("Results:", "AmendCheckSum", manual.AmendCheckSum)
+	return manual.AmendCheckSum, nil
 }
 
 var mermaidCmd = &cobra.Command{
 	Use:   "mermaid",
 	Short: "Mermaid",
 	Long:  "Mermaid",
-	Run:   mermaidMain,
+	RunE:  mermaidMain,
 }
 
 var dotCmd = &cobra.Command{
 	Use:   "dot",
 	Short: "Dot",
 	Long:  "Dot",
-	Run:   dotMain,
+	RunE:  dotMain,
 }
 
 var validateCmd = &cobra.Command{
 	Use:   "validate",
 	Short: "Validate",
 	Long:  "Validate",
-	Run:   validateMain,
-}
-
-func d5Main(cmd *cobra.Command, args []string){
-	_ = cmd.Help()
+	RunE:  validateMain,
 }
 
-func mermaidMain(cmd *cobra.Command, args []string) {
-	inputStr, err := loadInput(cmd, args)
-	MaybeDie(err)
+func d5LoadManual(cmd *cobra.Command, args []string) (Manual, error) {
+	inputStr, inputFile, err := loadInput(cmd, args)
+	if err != nil {
+		return Manual{}, err
+	}
 
 	manual, err := ParseInput(inputStr)
-	MaybeDie(err)
-
-	fmt.Printf("%v\n", manual.Rules.Mermaid())
+	if err != nil {
+		return manual, locateParseErr(err, inputStr, inputFile)
+	}
+	return manual, nil
 }
 
-func dotMain(cmd *cobra.Command, args []string) {
-	inputStr, err := loadInput(cmd, args)
-	MaybeDie(err)
-
-	manual, err := ParseInput(inputStr)
-	MaybeDie(err)
+func mermaidMain(cmd *cobra.Command, args []string) error {
+	manual, err := d5LoadManual(cmd, args)
+	if err != nil {
+		return err
+	}
 
-	fmt.Printf("%v\n", manual.Rules.Dot())
+	fmt.Printf("%v\n", manual.Rules.Mermaid())
+	return nil
 }
 
-func validateMain(cmd *cobra.Command, args []string) {
-	inputStr, err := loadInput(cmd, args)
-	MaybeDie(err)
-
-	manual, err := ParseInput(inputStr)
-	MaybeDie(err)
-
-	if !manual.Rules.HasCycle() {
-		Die("Rules DAG has at least one cycle")
+func dotMain(cmd *cobra.Command, args []string) error {
+	manual, err := d5LoadManual(cmd, args)
+	if err != nil {
+		return err
 	}
-}
-
-func d5p1Main(cmd *cobra.Command, args []string) {
-	inputStr, err := loadInput(cmd, args)
-	MaybeDie(err)
-
-	manual, err := ParseInput(inputStr)
-	MaybeDie(err)
 
-	manual.Validate()
-	slog.Debug("Results:", "ValidCheckSum", manual.ValidCheckSum)
-	fmt.Printf("%v\n", manual.ValidCheckSum)
+	fmt.Printf("%v\n", manual.Rules.Dot())
+	return nil
 }
 
-func d5p2Main(cmd *cobra.Command, args []string) {
-	inputStr, err := loadInput(cmd, args)
-	MaybeDie(err)
+func validateMain(cmd *cobra.Command, args []string) error {
+	manual, err := d5LoadManual(cmd, args)
+	if err != nil {
+		return err
+	}
 
-	manual, err := ParseInput(inputStr)
-	MaybeDie(err)
+	rules := graph.MakeDigraph(manual.Rules.Edges().Items()...)
+	cnd, err := rules.Condense(cmd.Context())
+	if err != nil {
+		return err
+	}
 
-	manual.Validate()
-	manual.Amend()
-	slog.Debug("Results:", "AmendCheckSum", manual.AmendCheckSum)
-	fmt.Printf("%v\n", manual.AmendCheckSum)
+	cycles := cnd.Cyclic()
+	for _, comp := range cycles {
+		pages := comp.Items()
+		slices.Sort(pages)
+		fmt.Printf("%v pages form a cycle: %v\n", len(pages), pages)
+	}
+	if len(cycles) > 0 {
+		return fmt.Errorf("Rules have cycles in %v of %v components", len(cycles), len(cnd.Comps))
+	}
+	return nil
 }
 
 type Update struct {
@@ -197,31 +198,33 @@ func (m *Manual) Amend() {
 	}
 }
 
+// The returned ParseError only knows the column, so the caller fills in the line number
 func ParseRule(line string) (int, int, error) {
-	parts := strings.Split(line, "|")
+	parts, offsets := splitAt(line, "|")
 	if len(parts) != 2 {
-		return 0, 0, fmt.Errorf("Found a malformed rule: %v", line)
+		return 0, 0, parseErr(-1, -1, line, "Found a malformed rule, expected one like 47|53")
 	}
 
 	left, err := strconv.Atoi(parts[0])
 	if err != nil {
-		return 0, 0, fmt.Errorf("Left side was not a number: %v", parts[0])
+		return 0, 0, parseErr(-1, offsets[0], line, "Left side was not a number: %q", parts[0])
 	}
 
 	right, err := strconv.Atoi(parts[1])
 	if err != nil {
-		return 0, 0, fmt.Errorf("Right side was not a number: %v", parts[1])
+		return 0, 0, parseErr(-1, offsets[1], line, "Right side was not a number: %q", parts[1])
 	}
 	return left, right, nil
 }
 
+// The returned ParseError only knows the column, so the caller fills in the line number
 func ParsePages(line string) ([]int, error) {
-	parts := strings.Split(line, ",")
+	parts, offsets := splitAt(line, ",")
 	pages := make([]int, len(parts))
 	for i, part := range parts {
 		val, err := strconv.Atoi(part)
 		if err != nil {
-			return nil, fmt.Errorf("Location was not a number at position %v: %v", i, part)
+			return nil, parseErr(-1, offsets[i], line, "Location was not a number: %q", part)
 		}
 		pages[i] = val
 	}
@@ -242,13 +245,13 @@ func ParseInput(inputStr string) (Manual, error) {
 		} else if onSinai {
 			left, right, err := ParseRule(line)
 			if err != nil {
-				return manual, fmt.Errorf("Bad rule on line %v: %#v", i, err)
+				return manual, atLine(err, i)
 			}
 			manual.AddRule(left, right)
 		} else {
 			pages, err := ParsePages(line)
 			if err != nil {
-				return manual, fmt.Errorf("Bad page list on line %v: %#v", i, err)
+				return manual, atLine(err, i)
 			}
 			manual.AddUpdate(pages)
 		}
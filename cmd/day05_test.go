@@ -1,6 +1,7 @@
 package cmd_test
 
 import (
+	"errors"
 	"log/slog"
 	"reflect"
 	"strings"
@@ -208,9 +209,14 @@ func TestParsePagesFail(t *testing.T) {
 	_, err := cmd.ParsePages("75,57,sixty-one,53,29")
 	if err == nil {
 		t.Fatalf("Did not get expected error from ParsePages: %#v", err)
-	} else if !strings.Contains(err.Error(), "Location was not a number at position 2: sixty-one") {
+	} else if !strings.Contains(err.Error(), `Location was not a number: "sixty-one"`) {
 		t.Fatalf(`ParsePages did not fail as expected on split`)
 	}
+
+	var parseErr *cmd.ParseError
+	if !errors.As(err, &parseErr) || parseErr.Col != 7 {
+		t.Fatalf("ParsePages did not point at the bad location: %#v", err)
+	}
 }
 
 func TestParseInput(t *testing.T) {
@@ -1,8 +1,8 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
-	"log/slog"
 	"os"
 	"strings"
 
@@ -11,86 +11,73 @@ import (
 )
 
 func init() {
-	rootCmd.AddCommand(d6Cmd)
-	d6Cmd.PersistentFlags().StringP("input-file", "i", "", "Get input from a file instead of stdin")
-	d6Cmd.PersistentFlags().StringP("output-file", "o", "", "Draw the final map and store it in the file")
-	d6Cmd.AddCommand(d6p1Cmd)
-	d6Cmd.AddCommand(d6p2Cmd)
+	Register(&d6Solver{})
 }
 
-var d6Cmd = &cobra.Command{
-	Use:   "day06",
-	Short: "Day 6 Solutions",
-	Long:  "The solutions for day 5 of Advent of Code 2025",
-	Run:   d6Main,
+type d6Solver struct {
+	outputFile string
 }
 
-var d6p1Cmd = &cobra.Command{
-	Use:   "part1",
-	Short: "Day 6, 1 Solution",
-	Long:  "The solution for day 5, part 1 of Advent of Code 2025",
-	Run:   d6p1Main,
+func (d *d6Solver) Day() int {
+	return 6
 }
 
-var d6p2Cmd = &cobra.Command{
-	Use:   "part2",
-	Short: "Day 6, 2 Solution",
-	Long:  "The solution for day 5, part 2 of Advent of Code 2025",
-	Run:   d6p2Main,
+func (d *d6Solver) Flags(part int, cmd *cobra.Command) {
+	if part == 0 {
+		cmd.PersistentFlags().StringVarP(&d.outputFile, "output-file", "o", "", "Draw the final map and store it in the file")
+	}
 }
 
-func d6Main(cmd *cobra.Command, args []string){
-	_ = cmd.Help()
+func (d *d6Solver) Parse(inputStr string) (any, error) {
+	return ParseLabMap(inputStr)
 }
 
-func d6p1Main(cmd *cobra.Command, args []string) {
-	inputStr, err := loadInput(cmd, args)
-	MaybeDie(err)
-
-	lm, err := ParseLabMap(inputStr)
-	MaybeDie(err)
+func (d *d6Solver) Part1(ctx context.Context, input any) (any, error) {
+	lm := input.(*LabMap)
 
-	err = lm.Patrol()
-	MaybeDie(err)
+	err := lm.Patrol(ctx)
+	if ctx.Err() != nil {
+		return nil, err
+	} else if err != nil {
+		return nil, &NoSolutionError{Msg: "The guard never leaves the lab", Err: err}
+	}
 
-	outputFile, err := cmd.Flags().GetString("output-file")
-	if err == nil && outputFile != "" {
-		err = os.WriteFile(outputFile, []byte(lm.String()), 0644)
+	if d.outputFile != "" {
+		err = os.WriteFile(d.outputFile, []byte(lm.String()), 0644)
 		if err != nil {
-			slog.Error("Couldn't write to output-file:", "file", outputFile, "err", err)
+			logger.Error("Couldn't write to output-file:", "file", d.outputFile, "err", err)
 		}
 	}
 
 	ct := lm.CountVisits()
 
-	slog.Debug("Results:", "VisitCount", ct)
-	fmt.Printf("%v\n", ct)
+	logger.Debug("Results:", "VisitCount", ct)
+	return ct, nil
 }
 
-func d6p2Main(cmd *cobra.Command, args []string) {
-	inputStr, err := loadInput(cmd, args)
-	MaybeDie(err)
+func (d *d6Solver) Part2(ctx context.Context, input any) (any, error) {
+	lm := input.(*LabMap)
 
-	lm, err := ParseLabMap(inputStr)
-	MaybeDie(err)
-
-	err = lm.Loopify()
-	MaybeDie(err)
+	err := lm.Loopify(ctx)
+	if ctx.Err() != nil {
+		return nil, err
+	} else if err != nil {
+		return nil, &NoSolutionError{Msg: "The guard never leaves the lab", Err: err}
+	}
 
-	outputFile, err := cmd.Flags().GetString("output-file")
-	if err == nil && outputFile != "" {
+	if d.outputFile != "" {
 		lm.ClearVisits()
-		slog.Debug("Dumping output as requested:", "file", outputFile)
-		err = os.WriteFile(outputFile, []byte(lm.String()), 0644)
+		logger.Debug("Dumping output as requested:", "file", d.outputFile)
+		err = os.WriteFile(d.outputFile, []byte(lm.String()), 0644)
 		if err != nil {
-			slog.Error("Couldn't write to output-file:", "file", outputFile, "err", err)
+			logger.Error("Couldn't write to output-file:", "file", d.outputFile, "err", err)
 		}
 	}
 
 	ct := lm.CountLoopers()
 
-	slog.Debug("Results:", "NewObstacleCount", ct)
-	fmt.Printf("%v\n", ct)
+	logger.Debug("Results:", "NewObstacleCount", ct)
+	return ct, nil
 }
 
 type LabMap struct {
@@ -104,22 +91,22 @@ type LabMap struct {
 
 func (lm *LabMap) Eq(om *LabMap) bool {
 	if lm.Size != om.Size {
-		slog.Debug("Sizes didn't match", "us", lm.Size, "them", om.Size)
+		logger.Debug("Sizes didn't match", "us", lm.Size, "them", om.Size)
 		return false
 	} else if lm.GuardPos != om.GuardPos {
-		slog.Debug("Guard positions didn't match", "us", lm.GuardPos, "them", om.GuardPos)
+		logger.Debug("Guard positions didn't match", "us", lm.GuardPos, "them", om.GuardPos)
 		return false
 	} else if lm.GuardDir != om.GuardDir {
-		slog.Debug("Guard directions didn't match", "us", lm.GuardDir, "them", om.GuardDir)
+		logger.Debug("Guard directions didn't match", "us", lm.GuardDir, "them", om.GuardDir)
 		return false
 	} else if !lm.Obstr.Eq(om.Obstr) {
-		slog.Debug("Obstructions didn't match", "us", lm.Obstr, "them", om.Obstr)
+		logger.Debug("Obstructions didn't match", "us", lm.Obstr, "them", om.Obstr)
 		return false
 	} else if !lm.Loopers.Eq(om.Loopers) {
-		slog.Debug("Looping obstuctions didn't match", "us", lm.Loopers, "them", om.Loopers)
+		logger.Debug("Looping obstuctions didn't match", "us", lm.Loopers, "them", om.Loopers)
 		return false
 	} else if !lm.Visits.Eq(&om.Visits) {
-		slog.Debug("Visits didn't match", "us", lm.Visits, "them", om.Visits)
+		logger.Debug("Visits didn't match", "us", lm.Visits, "them", om.Visits)
 	}
 	return true
 }
@@ -182,8 +169,11 @@ func (lm *LabMap) Walk() (bool, error) {
 	return true, nil
 }
 
-func (lm *LabMap) Patrol() error {
+func (lm *LabMap) Patrol(ctx context.Context) error {
 	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		stillIn, err := lm.Walk()
 		if err != nil {
 			return util.ReErr(err, "Patrol failed")
@@ -197,14 +187,16 @@ func (lm *LabMap) CountVisits() int {
 	return lm.Visits.Size()
 }
 
-func (lm *LabMap) Loopify() error {
+func (lm *LabMap) Loopify(ctx context.Context) error {
 	for {
 		oPos := lm.GuardPos.Add(lm.GuardDir)
 		if !lm.Size.Out(oPos) && !lm.Obstr.Has(oPos) && !lm.Visits.Has(oPos) {
 			om := lm.Clone()
 			om.Obstr.Add(oPos)
-			err := om.Patrol()
-			if err != nil {
+			err := om.Patrol(ctx)
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			} else if err != nil {
 				lm.Loopers.Add(oPos)
 			}
 		}
@@ -235,13 +227,10 @@ func ParseLabMap(inputStr string) (lm *LabMap, err error) {
 				return nil, util.ReErr(err, "Couldn't parse lab map")
 			}
 		} else if len(line) != lm.Size.W {
-			return nil, fmt.Errorf("Line #%v has a different size: %v", i, len(line))
+			return nil, parseErr(i, min(lm.Size.W, len(line)), line, "Expected %v cells like the first line, found %v", lm.Size.W, len(line))
 		}
 		for j, rn := range line {
 			pt := util.MakePoint(i, j)
-			if err != nil {
-				return nil, util.ReErr(err, "Bad point %v", pt)
-			}
 			switch rn {
 			case '#':
 				lm.Obstr.Add(pt)
@@ -253,6 +242,9 @@ func ParseLabMap(inputStr string) (lm *LabMap, err error) {
 				lm.Visit(pt, util.MakeVector(1, 0))
 			case '<':
 				lm.Visit(pt, util.MakeVector(0, -1))
+			case '.':
+			default:
+				return nil, parseErr(i, j, line, "Unknown map cell %q", rn)
 			}
 		}
 	}
@@ -1,6 +1,7 @@
 package cmd_test
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"testing"
@@ -149,7 +150,7 @@ func TestPatrol(t *testing.T) {
 	wantLm.GuardDir = util.MakeVector(-1, 0)
 	wantIn := false
 
-	lm.Patrol()
+	lm.Patrol(context.Background())
 	gotIn := !lm.Size.Out(lm.GuardPos)
 	if gotIn != wantIn {
 		t.Errorf("Mismatch 'in' status: want %v, got %v", wantIn, gotIn)
@@ -174,7 +175,7 @@ func TestCountVisits(t *testing.T) {
 		......#...
 	`)
 	util.Unexpect(t, err)
-	lm.Patrol()
+	lm.Patrol(context.Background())
 	want := 41
 	got  := lm.CountVisits()
 	if want != got {
@@ -196,7 +197,7 @@ func TestLoopify(t *testing.T) {
 		......#...
 	`)
 	util.Unexpect(t, err)
-	err = lm.Loopify()
+	err = lm.Loopify(context.Background())
 	util.Unexpect(t, err)
 	got := lm.CountLoopers()
 	want := 6
@@ -217,7 +218,7 @@ func TestLoopify(t *testing.T) {
 		..........
 	`)
 	util.Unexpect(t, err)
-	err = lm.Loopify()
+	err = lm.Loopify(context.Background())
 	util.Unexpect(t, err)
 	got = lm.CountLoopers()
 	fmt.Printf("\n\n%v\n", lm)
@@ -1,71 +1,48 @@
 package cmd
 
 import (
-	"fmt"
-	"log/slog"
+	"context"
 	"math"
 	"strconv"
 	"strings"
 
 	"github.com/dusktreader/advent-of-code-2024/util"
-	"github.com/spf13/cobra"
 )
 
 func init() {
-	rootCmd.AddCommand(d7Cmd)
-	d7Cmd.PersistentFlags().StringP("input-file", "i", "", "Get input from a file instead of stdin")
-	d7Cmd.PersistentFlags().StringP("output-file", "o", "", "Draw the final map and store it in the file")
-	d7Cmd.AddCommand(d7p1Cmd)
-	d7Cmd.AddCommand(d7p2Cmd)
+	Register(&d7Solver{})
 }
 
-var d7Cmd = &cobra.Command{
-	Use:   "day07",
-	Short: "Day 7 Solutions",
-	Long:  "The solutions for day 7 of Advent of Code 2025",
-	Run:   d7Main,
-}
-
-var d7p1Cmd = &cobra.Command{
-	Use:   "part1",
-	Short: "Day 7, 1 Solution",
-	Long:  "The solution for day 7, part 1 of Advent of Code 2025",
-	Run:   d7p1Main,
-}
+type d7Solver struct{}
 
-var d7p2Cmd = &cobra.Command{
-	Use:   "part2",
-	Short: "Day 7, 2 Solution",
-	Long:  "The solution for day 7, part 2 of Advent of Code 2025",
-	Run:   d7p2Main,
+func (d *d7Solver) Day() int {
+	return 7
 }
 
-func d7Main(cmd *cobra.Command, args []string){
-	_ = cmd.Help()
+func (d *d7Solver) Parse(inputStr string) (any, error) {
+	return ParseEquations(inputStr)
 }
 
-func d7p1Main(cmd *cobra.Command, args []string) {
-	inputStr, err := loadInput(cmd, args)
-	MaybeDie(err)
-
-	eqs, err := ParseEquations(inputStr)
-	MaybeDie(err)
+func (d *d7Solver) Part1(ctx context.Context, input any) (any, error) {
+	eqs := input.([]Equation)
 
-	total := EqTotal2(eqs)
-	slog.Debug("Results:", "Total", total)
-	fmt.Printf("%v\n", total)
+	total, err := EqTotal2(ctx, eqs)
+	if err != nil {
+		return nil, err
+	}
+	logger.Debug("Results:", "Total", total)
+	return total, nil
 }
 
-func d7p2Main(cmd *cobra.Command, args []string) {
-	inputStr, err := loadInput(cmd, args)
-	MaybeDie(err)
-
-	eqs, err := ParseEquations(inputStr)
-	MaybeDie(err)
+func (d *d7Solver) Part2(ctx context.Context, input any) (any, error) {
+	eqs := input.([]Equation)
 
-	total := EqTotal3(eqs)
-	slog.Debug("Results:", "Total", total)
-	fmt.Printf("%v\n", total)
+	total, err := EqTotal3(ctx, eqs)
+	if err != nil {
+		return nil, err
+	}
+	logger.Debug("Results:", "Total", total)
+	return total, nil
 }
 
 type Equation struct {
@@ -142,26 +119,32 @@ func (eq Equation) Process3() {
 	}
 }
 
-func EqTotal2(eqs []Equation) int {
+func EqTotal2(ctx context.Context, eqs []Equation) (int, error) {
 	total := 0
 	for _, eq := range eqs {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
 		eq.Process2()
 		if !eq.Sats.Empty() {
 			total += eq.Left
 		}
 	}
-	return total
+	return total, nil
 }
 
-func EqTotal3(eqs []Equation) int {
+func EqTotal3(ctx context.Context, eqs []Equation) (int, error) {
 	total := 0
 	for _, eq := range eqs {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
 		eq.Process3()
 		if !eq.Sats.Empty() {
 			total += eq.Left
 		}
 	}
-	return total
+	return total, nil
 }
 
 func ParseEquations(inputStr string) ([]Equation, error) {
@@ -170,19 +153,25 @@ func ParseEquations(inputStr string) ([]Equation, error) {
 	eqs := make([]Equation, len(lines))
 	for i, line := range lines {
 		line = strings.TrimSpace(line)
-		parts := strings.Split(line, ":")
+		sep := strings.Index(line, ":")
+		if sep < 0 {
+			return nil, parseErr(i, len(line), line, "Expected ':' after the test value")
+		}
 
-		left, err := strconv.Atoi(parts[0])
+		left, err := strconv.Atoi(line[:sep])
 		if err != nil {
-			return nil, util.ReErr(err, "Couldn't convert left side %v", parts[0])
+			return nil, parseErr(i, 0, line, "Test value %q is not a number", line[:sep])
 		}
 
-		parts = strings.Split(strings.TrimSpace(parts[1]), " ")
+		parts, offsets := fieldsAt(line[sep + 1:])
+		if len(parts) == 0 {
+			return nil, parseErr(i, len(line), line, "Expected at least one number after ':'")
+		}
 		right := make([]int, len(parts))
 		for j, p := range parts {
 			r, err := strconv.Atoi(p)
 			if err != nil {
-				return nil, util.ReErr(err, "Couldn't convert line %v right side %v at index %v", i, p, j)
+				return nil, parseErr(i, sep + 1 + offsets[j], line, "Operand %q is not a number", p)
 			}
 			right[j] = r
 		}
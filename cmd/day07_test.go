@@ -1,6 +1,8 @@
 package cmd_test
 
 import (
+	"context"
+	"errors"
 	"log/slog"
 	"testing"
 
@@ -41,6 +43,28 @@ func TestParseEquations(t *testing.T) {
 	}
 }
 
+func TestParseEquationsFail(t *testing.T) {
+	cases := []struct{
+		txt  string
+		line int
+		col  int
+	}{
+		{"190: 10 19\n3267 81 40 27", 2, 14},
+		{"190: 10 19\n3267: 81 4o 27", 2, 10},
+		{"19O: 10 19", 1, 1},
+		{"190:", 1, 5},
+	}
+	for _, c := range cases {
+		_, err := cmd.ParseEquations(c.txt)
+		var parseErr *cmd.ParseError
+		if !errors.As(err, &parseErr) {
+			t.Errorf("ParseEquations didn't give a ParseError for %q: %#v", c.txt, err)
+		} else if parseErr.Line != c.line || parseErr.Col != c.col {
+			t.Errorf("Wrong position for %q: want %v:%v, got %v:%v", c.txt, c.line, c.col, parseErr.Line, parseErr.Col)
+		}
+	}
+}
+
 func TestCatBasic(t *testing.T) {
 	slog.SetLogLoggerLevel(slog.LevelDebug)
 	want := 12345
@@ -245,7 +269,8 @@ func TestEqTotal2(t *testing.T) {
 	util.Unexpect(t, err)
 
 	want := 3749
-	got  := cmd.EqTotal2(eqs)
+	got, err := cmd.EqTotal2(context.Background(), eqs)
+	util.Unexpect(t, err)
 	if want != got {
 		t.Fatalf("EqTotal failed: wanted %v, got %v", want, got)
 	}
@@ -266,7 +291,8 @@ func TestEqTotal3(t *testing.T) {
 	util.Unexpect(t, err)
 
 	want := 11387
-	got  := cmd.EqTotal3(eqs)
+	got, err := cmd.EqTotal3(context.Background(), eqs)
+	util.Unexpect(t, err)
 	if want != got {
 		t.Fatalf("EqTotal failed: wanted %v, got %v", want, got)
 	}
@@ -1,75 +1,47 @@
 package cmd
 
 import (
-	"fmt"
-	"log/slog"
+	"context"
 	"strings"
+	"unicode"
 
 	"github.com/dusktreader/advent-of-code-2024/util"
-	"github.com/spf13/cobra"
 )
 
 func init() {
-	rootCmd.AddCommand(d8Cmd)
-	d8Cmd.PersistentFlags().StringP("input-file", "i", "", "Get input from a file instead of stdin")
-	d8Cmd.PersistentFlags().StringP("output-file", "o", "", "Draw the final map and store it in the file")
-	d8Cmd.AddCommand(d8p1Cmd)
-	d8Cmd.AddCommand(d8p2Cmd)
+	Register(&d8Solver{})
 }
 
-var d8Cmd = &cobra.Command{
-	Use:   "day08",
-	Short: "Day 8 Solutions",
-	Long:  "The solutions for day 8 of Advent of Code 2025",
-	Run:   d8Main,
-}
-
-var d8p1Cmd = &cobra.Command{
-	Use:   "part1",
-	Short: "Day 8, 1 Solution",
-	Long:  "The solution for day 8, part 1 of Advent of Code 2025",
-	Run:   d8p1Main,
-}
+type d8Solver struct{}
 
-var d8p2Cmd = &cobra.Command{
-	Use:   "part2",
-	Short: "Day 8, 2 Solution",
-	Long:  "The solution for day 8, part 2 of Advent of Code 2025",
-	Run:   d8p2Main,
+func (d *d8Solver) Day() int {
+	return 8
 }
 
-func d8Main(cmd *cobra.Command, args []string){
-	_ = cmd.Help()
+func (d *d8Solver) Parse(inputStr string) (any, error) {
+	return ParseAntMap(inputStr)
 }
 
-func d8p1Main(cmd *cobra.Command, args []string) {
-	inputStr, err := loadInput(cmd, args)
-	MaybeDie(err)
-
-	am, err := ParseAntMap(inputStr)
-	MaybeDie(err)
+func (d *d8Solver) Part1(ctx context.Context, input any) (any, error) {
+	am := input.(*AntMap)
 
 	am.FindAll(false)
 
 	ct := am.CountAns()
 
-	slog.Debug("Results:", "AntinodeCount", ct)
-	fmt.Printf("%v\n", ct)
+	logger.Debug("Results:", "AntinodeCount", ct)
+	return ct, nil
 }
 
-func d8p2Main(cmd *cobra.Command, args []string) {
-	inputStr, err := loadInput(cmd, args)
-	MaybeDie(err)
-
-	am, err := ParseAntMap(inputStr)
-	MaybeDie(err)
+func (d *d8Solver) Part2(ctx context.Context, input any) (any, error) {
+	am := input.(*AntMap)
 
 	am.FindAll(true)
 
 	ct := am.CountAns()
 
-	slog.Debug("Results:", "AntinodeCount", ct)
-	fmt.Printf("%v\n", ct)
+	logger.Debug("Results:", "AntinodeCount", ct)
+	return ct, nil
 }
 
 type AntMap struct {
@@ -91,10 +63,10 @@ func MakeAntMap(w int, h int) (*AntMap, error) {
 
 func (am *AntMap) Eq(om *AntMap) bool {
 	if am.Size != om.Size {
-		slog.Debug("Sizes didn't match", "us", am.Size, "them", om.Size)
+		logger.Debug("Sizes didn't match", "us", am.Size, "them", om.Size)
 		return false
 	} else if !am.Ants.Eq(&om.Ants) {
-		slog.Debug("Antenna didn't match", "us", am.Ants, "them", om.Ants)
+		logger.Debug("Antenna didn't match", "us", am.Ants, "them", om.Ants)
 		return false
 	}
 	return true
@@ -196,16 +168,15 @@ func ParseAntMap(inputStr string) (am *AntMap, err error) {
 				return nil, util.ReErr(err, "Couldn't parse antenna map")
 			}
 		} else if len(line) != am.Size.W {
-			return nil, fmt.Errorf("Line #%v has a different size: %v", i, len(line))
+			return nil, parseErr(i, min(am.Size.W, len(line)), line, "Expected %v cells like the first line, found %v", am.Size.W, len(line))
 		}
 		for j, rn := range line {
 			if rn == '.' {
 				continue
+			} else if !unicode.IsLetter(rn) && !unicode.IsDigit(rn) {
+				return nil, parseErr(i, j, line, "Antenna frequency %q should be a letter or digit", rn)
 			}
 			pt := util.MakePoint(i, j)
-			if err != nil {
-				return nil, util.ReErr(err, "Bad point %v", pt)
-			}
 			am.Ants.Add(rn, pt)
 		}
 	}
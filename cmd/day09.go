@@ -1,75 +1,49 @@
 package cmd
 
 import (
-	"fmt"
-	"log/slog"
+	"context"
 	"strconv"
 	"strings"
 
 	"github.com/dusktreader/advent-of-code-2024/util"
-	"github.com/spf13/cobra"
 )
 
 func init() {
-	rootCmd.AddCommand(d9Cmd)
-	d9Cmd.PersistentFlags().StringP("input-file", "i", "", "Get input from a file instead of stdin")
-	d9Cmd.PersistentFlags().StringP("output-file", "o", "", "Draw the final map and store it in the file")
-	d9Cmd.AddCommand(d9p1Cmd)
-	d9Cmd.AddCommand(d9p2Cmd)
+	Register(&d9Solver{})
 }
 
-var d9Cmd = &cobra.Command{
-	Use:   "day09",
-	Short: "Day 9 Solutions",
-	Long:  "The solutions for day 9 of Advent of Code 2025",
-	Run:   d9Main,
-}
-
-var d9p1Cmd = &cobra.Command{
-	Use:   "part1",
-	Short: "Day 9, 1 Solution",
-	Long:  "The solution for day 9, part 1 of Advent of Code 2025",
-	Run:   d9p1Main,
-}
+type d9Solver struct{}
 
-var d9p2Cmd = &cobra.Command{
-	Use:   "part2",
-	Short: "Day 9, 2 Solution",
-	Long:  "The solution for day 9, part 2 of Advent of Code 2025",
-	Run:   d9p2Main,
+func (d *d9Solver) Day() int {
+	return 9
 }
 
-func d9Main(cmd *cobra.Command, args []string){
-	_ = cmd.Help()
+func (d *d9Solver) Parse(inputStr string) (any, error) {
+	return ParseFiles(inputStr)
 }
 
-func d9p1Main(cmd *cobra.Command, args []string) {
-	inputStr, err := loadInput(cmd, args)
-	MaybeDie(err)
-
-	files, err := ParseFiles(inputStr)
-	MaybeDie(err)
+func (d *d9Solver) Part1(ctx context.Context, input any) (any, error) {
+	files := input.([]int)
 
 	compact := CompactFilesDense(files)
 	checksum := ComputeChecksumCompact(compact)
 
-	slog.Debug("Results:", "Checksum", checksum)
-	fmt.Printf("%v\n", checksum)
+	logger.Debug("Results:", "Checksum", checksum)
+	return checksum, nil
 }
 
-func d9p2Main(cmd *cobra.Command, args []string) {
-	inputStr, err := loadInput(cmd, args)
-	MaybeDie(err)
-
-	files, err := ParseFiles(inputStr)
-	MaybeDie(err)
+func (d *d9Solver) Part2(ctx context.Context, input any) (any, error) {
+	files := input.([]int)
 
 	slots := ExpandSlots(files)
-	slots = CompactFilesSparse(slots)
+	slots, err := CompactFilesSparse(ctx, slots)
+	if err != nil {
+		return nil, err
+	}
 	checksum := ComputeChecksumSparse(slots)
 
-	slog.Debug("Results:", "Checksum", checksum)
-	fmt.Printf("%v\n", checksum)
+	logger.Debug("Results:", "Checksum", checksum)
+	return checksum, nil
 }
 
 func ParseFiles(inputStr string) ([]int, error) {
@@ -77,10 +51,10 @@ func ParseFiles(inputStr string) ([]int, error) {
 	l := len(inputStr)
 	files := make([]int, l)
 	for i := 0; i < l; i++ {
-		v := int(inputStr[i] - '0')
-		if v < 0 || v > 9 {
-			return []int{}, fmt.Errorf("Couldn't convert token at index %v", i)
+		if inputStr[i] < '0' || inputStr[i] > '9' {
+			return []int{}, parseErr(0, i, inputStr, "Block size %q is not a digit", inputStr[i])
 		}
+		v := int(inputStr[i] - '0')
 		files[i] = v
 	}
 	return files, nil
@@ -161,8 +135,11 @@ type FileSlot struct {
 	Ct int
 }
 
-func CompactFilesSparse(slots []FileSlot) []FileSlot {
+func CompactFilesSparse(ctx context.Context, slots []FileSlot) ([]FileSlot, error) {
 	for r := len(slots) - 1; r > 0; r-- {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		if slots[r].Id == EMPTY {
 			continue
 		}
@@ -173,20 +150,20 @@ func CompactFilesSparse(slots []FileSlot) []FileSlot {
 
 			d := slots[l].Ct - slots[r].Ct
 			if d >= 0 {
-				slog.Debug("Found a slot!", "l", l, "l.Ct", slots[l].Ct)
+				logger.Debug("Found a slot!", "l", l, "l.Ct", slots[l].Ct)
 				slots[l].Id = slots[r].Id
 				slots[l].Ct = slots[r].Ct
 				slots[r].Id = EMPTY
 				if d > 0 {
-					slog.Debug("Inserting a smaller empty!", "l+1", l + 1, "Ct", d)
+					logger.Debug("Inserting a smaller empty!", "l+1", l + 1, "Ct", d)
 					slots = util.Insert(slots, l + 1, FileSlot{Id: EMPTY, Ct: d})
 				}
-				slog.Debug("Now slots look like:", "slots", slots)
+				logger.Debug("Now slots look like:", "slots", slots)
 				break
 			}
 		}
 	}
-	return slots
+	return slots, nil
 }
 
 func PrintSlots(slots []FileSlot) string {
@@ -1,6 +1,8 @@
 package cmd_test
 
 import (
+	"context"
+	"errors"
 	"reflect"
 	"testing"
 
@@ -93,7 +95,9 @@ func TestCompactFilesSparse(t *testing.T) {
 	slots := cmd.ExpandSlots(files)
 
 	want := "00992111777.44.333....5555.6666.....8888.."
-	got  := cmd.PrintSlots(cmd.CompactFilesSparse(slots))
+	slots, err = cmd.CompactFilesSparse(context.Background(), slots)
+	util.Unexpect(t, err)
+	got  := cmd.PrintSlots(slots)
 
 	if !reflect.DeepEqual(want, got) {
 		t.Fatalf("Failed:\n\nwant\n%+v,\n\ngot\n%+v", want, got)
@@ -104,7 +108,8 @@ func TestComputeChecksumSparse(t *testing.T) {
 	files, err := cmd.ParseFiles("2333133121414131402")
 	util.Unexpect(t, err)
 	slots := cmd.ExpandSlots(files)
-	slots = cmd.CompactFilesSparse(slots)
+	slots, err = cmd.CompactFilesSparse(context.Background(), slots)
+	util.Unexpect(t, err)
 
 	want := 2858
 	got  := cmd.ComputeChecksumSparse(slots)
@@ -112,3 +117,15 @@ func TestComputeChecksumSparse(t *testing.T) {
 		t.Fatalf("Failed: want %v, got %v", want, got)
 	}
 }
+
+func TestCompactFilesSparseCancel(t *testing.T) {
+	files, err := cmd.ParseFiles("2333133121414131402")
+	util.Unexpect(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = cmd.CompactFilesSparse(ctx, cmd.ExpandSlots(files))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected compaction to stop when cancelled, got %v", err)
+	}
+}
@@ -1,8 +1,8 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
-	"log/slog"
 	"strings"
 
 	"github.com/dusktreader/advent-of-code-2024/graph"
@@ -11,104 +11,101 @@ import (
 )
 
 func init() {
-	rootCmd.AddCommand(d10Cmd)
-	d10Cmd.PersistentFlags().StringP("input-file", "i", "", "Get input from a file instead of stdin")
-	d10Cmd.AddCommand(d10p1Cmd)
-	d10Cmd.AddCommand(d10p2Cmd)
-	d10Cmd.AddCommand(showCmd)
+	Register(&d10Solver{})
 }
 
-var d10Cmd = &cobra.Command{
-	Use:   "day10",
-	Short: "Day 10 Solutions",
-	Long:  "The solutions for day 10 of Advent of Code 2025",
-	Run:   d10Main,
-}
+type d10Solver struct{}
 
-var d10p1Cmd = &cobra.Command{
-	Use:   "part1",
-	Short: "Day 10, 1 Solution",
-	Long:  "The solution for day 10, part 1 of Advent of Code 2025",
-	Run:   d10p1Main,
+func (d *d10Solver) Day() int {
+	return 10
 }
 
-var d10p2Cmd = &cobra.Command{
-	Use:   "part2",
-	Short: "Day 10, 2 Solution",
-	Long:  "The solution for day 10, part 2 of Advent of Code 2025",
-	Run:   d10p2Main,
+func (d *d10Solver) Commands() []*cobra.Command {
+	return []*cobra.Command{showCmd}
 }
 
-var showCmd = &cobra.Command{
-	Use:   "show",
-	Short: "Show",
-	Long:  "Show",
-	Run:   showMain,
+func (d *d10Solver) Parse(inputStr string) (any, error) {
+	return ParseTopoMap(inputStr)
 }
 
-func d10Main(cmd *cobra.Command, args []string){
-	_ = cmd.Help()
-}
+func (d *d10Solver) Part1(ctx context.Context, input any) (any, error) {
+	topo := input.(*TopoMap)
 
-func d10p1Main(cmd *cobra.Command, args []string) {
-	inputStr, err := loadInput(cmd, args)
-	MaybeDie(err)
-
-	topo, err := ParseTopoMap(inputStr)
-	MaybeDie(err)
-
-	ct := topo.CountTrails()
+	ct, err := topo.CountTrails(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	slog.Debug("Results:", "TrailCount", ct)
-	fmt.Printf("%v\n", ct)
+	logger.Debug("Results:", "TrailCount", ct)
+	return ct, nil
 }
 
-func d10p2Main(cmd *cobra.Command, args []string) {
-	inputStr, err := loadInput(cmd, args)
-	MaybeDie(err)
+func (d *d10Solver) Part2(ctx context.Context, input any) (any, error) {
+	topo := input.(*TopoMap)
 
-	topo, err := ParseTopoMap(inputStr)
-	MaybeDie(err)
+	rt, err := topo.RateTrails(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	rt := topo.RateTrails()
+	logger.Debug("Results:", "Rating", rt)
+	return rt, nil
+}
 
-	slog.Debug("Results:", "Rating", rt)
-	fmt.Printf("%v\n", rt)
+var showCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show",
+	Long:  "Show",
+	RunE:  showMain,
 }
 
-func showMain(cmd *cobra.Command, args []string) {
-	inputStr, err := loadInput(cmd, args)
-	MaybeDie(err)
+func showMain(cmd *cobra.Command, args []string) error {
+	inputStr, inputFile, err := loadInput(cmd, args)
+	if err != nil {
+		return err
+	}
 
 	topo, err := ParseTopoMap(inputStr)
-	MaybeDie(err)
+	if err != nil {
+		return locateParseErr(err, inputStr, inputFile)
+	}
 
 	fmt.Printf("%v\n", topo)
+	return nil
 }
 
-func (tm *TopoMap) CountTrails() int {
+func (tm *TopoMap) CountTrails(ctx context.Context) (int, error) {
 	count := 0
 	for th := range tm.THs.Iter() {
-		for top := range tm.Tops.Iter() {
-			if tm.DAG.HasPath(th, top) {
+		reached, err := tm.DAG.Distances(ctx, th)
+		if err != nil {
+			return 0, err
+		}
+		for top := range reached {
+			if tm.Tops.Has(top) {
 				count++
 			}
 		}
 	}
-	return count
+	return count, nil
 }
 
-func (tm *TopoMap) RateTrails() int {
-	rating := 0
-	for th := range tm.THs.Iter() {
-		for top := range tm.Tops.Iter() {
-			paths := tm.DAG.Paths(th, top)
-			rating += len(paths)
-		}
+func (tm *TopoMap) RateTrails(ctx context.Context) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
 	}
-	return rating
+
+	rating, err := tm.DAG.CountPathsBetween(tm.THs, tm.Tops)
+	if err != nil {
+		return 0, util.ReErr(err, "Couldn't count trails")
+	} else if !rating.IsInt64() {
+		return 0, fmt.Errorf("Trail rating %v is too big", rating)
+	}
+	return int(rating.Int64()), nil
 }
 
+const impassable = -1
+
 type TopoMap struct {
 	Size  util.Size
 	Elevs []int
@@ -143,6 +140,10 @@ func (tm *TopoMap) String() string {
 		if err != nil {
 			return fmt.Sprintf("ERROR RENDERING POINT: Couldn't get offset at %v", off)
 		}
+		if elev == impassable {
+			runes[idx] = '.'
+			continue
+		}
 
 		rn, err := util.ItoR(elev)
 		if err != nil {
@@ -185,14 +186,12 @@ func (tm *TopoMap) String() string {
 	return out
 }
 
+// Trails only go up one step at a time. Cells marked '.' are impassable
 func ParseTopoMap(inputStr string) (tm *TopoMap, err error) {
 	inputStr = strings.TrimSpace(inputStr)
 
 	lines := strings.Split(inputStr, "\n")
-	west := util.MakeVector(0, -1)
-	north := util.MakeVector(-1, 0)
 	tm = &TopoMap{
-		DAG:  graph.MakeGraph[util.Point](true),
 		Size: util.Size{W: 0, H: len(lines)},
 		THs:  util.MakeSet[util.Point](),
 		Tops: util.MakeSet[util.Point](),
@@ -204,46 +203,37 @@ func ParseTopoMap(inputStr string) (tm *TopoMap, err error) {
 			tm.Size.W = len(line)
 			tm.Elevs = make([]int, tm.Size.W * tm.Size.H)
 		} else if len(line) != tm.Size.W {
-			return nil, fmt.Errorf("Line #%v has a different size: %v", i, len(line))
+			return nil, parseErr(i, min(tm.Size.W, len(line)), line, "Expected %v cells like the first line, found %v", tm.Size.W, len(line))
 		}
 		for j, rn := range line {
+			pt := util.MakePoint(i, j)
 			if rn == '.' {
+				tm.Elevs[pt.I * tm.Size.W + pt.J] = impassable
 				continue
 			}
 
-			pt := util.MakePoint(i, j)
 			elev, err := util.RtoI(rn)
 			if err != nil {
-				return nil, util.ReErr(err, "Found invalid elevation at %+v", pt)
+				return nil, parseErr(i, j, line, "Elevation %q is not a digit", rn)
 			}
 			if elev == 0 {
 				tm.THs.Add(pt)
 			} else if elev == 9 {
 				tm.Tops.Add(pt)
 			}
-
 			tm.Elevs[pt.I * tm.Size.W + pt.J] = elev
-
-			nbor := pt.Add(west)
-			if nelev, err := tm.GetElev(nbor); err == nil {
-				d := nelev - elev
-				if d == -1 {
-					tm.DAG.AddEdge(nbor, pt)
-				} else if d == 1 {
-					tm.DAG.AddEdge(pt, nbor)
-				}
-			}
-
-			nbor = pt.Add(north)
-			if nelev, err := tm.GetElev(nbor); err == nil {
-				d := nelev - elev
-				if d == -1 {
-					tm.DAG.AddEdge(nbor, pt)
-				} else if d == 1 {
-					tm.DAG.AddEdge(pt, nbor)
-				}
-			}
 		}
 	}
+
+	grid, err := util.MakeGrid(tm.Size, tm.Elevs)
+	if err != nil {
+		return nil, err
+	}
+	tm.DAG, err = graph.FromGrid(grid, graph.NEIGHBORS4, true, func(from int, to int) (int, bool) {
+		return 1, from != impassable && to - from == 1
+	})
+	if err != nil {
+		return nil, err
+	}
 	return tm, nil
 }
@@ -1,7 +1,7 @@
 package cmd_test
 
 import (
-	"reflect"
+	"context"
 	"testing"
 
 	"github.com/dusktreader/advent-of-code-2024/cmd"
@@ -46,8 +46,37 @@ func TestParseTopoMap(t *testing.T) {
 	got, err := cmd.ParseTopoMap(inputStr)
 	util.Unexpect(t, err)
 
-	if !reflect.DeepEqual(want, got) {
-		t.Errorf("Failed: want %+v, got %+v", want, got)
+	if !want.Eq(got.DAG) {
+		t.Errorf("Failed: want %+v, got %+v", want, got.DAG)
 	}
 }
 
+
+func TestRateTrails(t *testing.T) {
+	inputStr := `
+		89010123
+		78121874
+		87430965
+		96549874
+		45678903
+		32019012
+		01329801
+		10456732
+	`
+	tm, err := cmd.ParseTopoMap(inputStr)
+	util.Unexpect(t, err)
+
+	want := 81
+	got, err := tm.RateTrails(context.Background())
+	util.Unexpect(t, err)
+	if want != got {
+		t.Errorf("Wrong rating: want %v, got %v", want, got)
+	}
+
+	want = 36
+	got, err = tm.CountTrails(context.Background())
+	util.Unexpect(t, err)
+	if want != got {
+		t.Errorf("Wrong trail count: want %v, got %v", want, got)
+	}
+}
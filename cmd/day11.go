@@ -1,8 +1,8 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
-	"log/slog"
 	"math"
 	"strconv"
 	"strings"
@@ -12,86 +12,72 @@ import (
 )
 
 func init() {
-	rootCmd.AddCommand(d11Cmd)
-	d11Cmd.PersistentFlags().StringP("input-file", "i", "", "Get input from a file instead of stdin")
-	d11Cmd.AddCommand(d11p1Cmd)
-	d11Cmd.AddCommand(d11p2Cmd)
-	d11Cmd.AddCommand(blinkCmd)
+	Register(&d11Solver{})
 	blinkCmd.Flags().IntP("count", "n", 75, "Blink n times")
 }
 
-var d11Cmd = &cobra.Command{
-	Use:   "day11",
-	Short: "Day 11 Solutions",
-	Long:  "The solutions for day 11 of Advent of Code 2025",
-	Run:   d11Main,
-}
+type d11Solver struct{}
 
-var d11p1Cmd = &cobra.Command{
-	Use:   "part1",
-	Short: "Day 11, 1 Solution",
-	Long:  "The solution for day 11, part 1 of Advent of Code 2025",
-	Run:   d11p1Main,
+func (d *d11Solver) Day() int {
+	return 11
 }
 
-var d11p2Cmd = &cobra.Command{
-	Use:   "part2",
-	Short: "Day 11, 2 Solution",
-	Long:  "The solution for day 11, part 2 of Advent of Code 2025",
-	Run:   d11p2Main,
+func (d *d11Solver) Commands() []*cobra.Command {
+	return []*cobra.Command{blinkCmd}
 }
 
-var blinkCmd = &cobra.Command{
-	Use:   "blink",
-	Short: "Blink n times",
-	Long:  "Blink a custom number of times",
-	Run:   blinkMain,
+func (d *d11Solver) Parse(inputStr string) (any, error) {
+	return ParseStones(inputStr)
 }
 
-func d11Main(cmd *cobra.Command, args []string){
-	_ = cmd.Help()
-}
-
-func d11p1Main(cmd *cobra.Command, args []string) {
-	inputStr, err := loadInput(cmd, args)
-	MaybeDie(err)
-
-	stones, err := ParseStones(inputStr)
-	MaybeDie(err)
+func (d *d11Solver) Part1(ctx context.Context, input any) (any, error) {
+	stones := input.([]int)
 
 	ct := CountStones(stones, 25)
 
-	slog.Debug("Results:", "StoneCount", ct)
-	fmt.Printf("%v\n", ct)
+	logger.Debug("Results:", "StoneCount", ct)
+	return ct, nil
 }
 
-func d11p2Main(cmd *cobra.Command, args []string) {
-	inputStr, err := loadInput(cmd, args)
-	MaybeDie(err)
-
-	stones, err := ParseStones(inputStr)
-	MaybeDie(err)
+func (d *d11Solver) Part2(ctx context.Context, input any) (any, error) {
+	stones := input.([]int)
 
 	ct := CountStones(stones, 75)
 
-	slog.Debug("Results:", "StoneCount", ct)
-	fmt.Printf("%v\n", ct)
+	logger.Debug("Results:", "StoneCount", ct)
+	return ct, nil
 }
 
-func blinkMain(cmd *cobra.Command, args []string) {
+var blinkCmd = &cobra.Command{
+	Use:   "blink",
+	Short: "Blink n times",
+	Long:  "Blink a custom number of times",
+	RunE:  blinkMain,
+}
+
+func blinkMain(cmd *cobra.Command, args []string) error {
 	blinks, err := cmd.Flags().GetInt("count")
-	MaybeDie(err)
+	if err != nil {
+		return &FlagError{Flag: "count", Err: err}
+	} else if blinks < 0 {
+		return &FlagError{Flag: "count", Err: fmt.Errorf("Can't blink %v times", blinks)}
+	}
 
-	inputStr, err := loadInput(cmd, args)
-	MaybeDie(err)
+	inputStr, inputFile, err := loadInput(cmd, args)
+	if err != nil {
+		return err
+	}
 
 	stones, err := ParseStones(inputStr)
-	MaybeDie(err)
+	if err != nil {
+		return locateParseErr(err, inputStr, inputFile)
+	}
 
 	ct := CountStones(stones, blinks)
 
-	slog.Debug("Results:", "StoneCount", ct)
+	logger.Debug("Results:", "StoneCount", ct)
 	fmt.Printf("%v\n", ct)
+	return nil
 }
 
 func Split(v int) (int, int, bool) {
@@ -147,12 +133,12 @@ func CountStones(stones []int, blinks int) int {
 
 func ParseStones(inputStr string) ([]int, error) {
 	inputStr = strings.TrimSpace(inputStr)
-	tokens := strings.Split(inputStr, " ")
+	tokens, offsets := fieldsAt(inputStr)
 	stones := make([]int, len(tokens))
 	for i, t := range tokens {
 		s, err := strconv.Atoi(t)
 		if err != nil {
-			return []int{}, fmt.Errorf("Couldn't convert stone value at index %v", i)
+			return []int{}, parseErr(0, offsets[i], inputStr, "Stone %q is not a number", t)
 		}
 		stones[i] = s
 	}
@@ -1,80 +1,53 @@
 package cmd
 
 import (
-	"fmt"
-	"log/slog"
+	"context"
 	"strings"
 
 	"github.com/dusktreader/advent-of-code-2024/util"
-	"github.com/spf13/cobra"
 )
 
 func init() {
-	rootCmd.AddCommand(d12Cmd)
-	d12Cmd.PersistentFlags().StringP("input-file", "i", "", "Get input from a file instead of stdin")
-	d12Cmd.AddCommand(d12p1Cmd)
-	d12Cmd.AddCommand(d12p2Cmd)
+	Register(&d12Solver{})
 }
 
-var d12Cmd = &cobra.Command{
-	Use:   "day12",
-	Short: "Day 12 Solutions",
-	Long:  "The solutions for day 12 of Advent of Code 2025",
-	Run:   d12Main,
-}
-
-var d12p1Cmd = &cobra.Command{
-	Use:   "part1",
-	Short: "Day 12, 1 Solution",
-	Long:  "The solution for day 12, part 1 of Advent of Code 2025",
-	Run:   d12p1Main,
-}
+type d12Solver struct{}
 
-var d12p2Cmd = &cobra.Command{
-	Use:   "part2",
-	Short: "Day 12, 2 Solution",
-	Long:  "The solution for day 12, part 2 of Advent of Code 2025",
-	Run:   d12p2Main,
+func (d *d12Solver) Day() int {
+	return 12
 }
 
-func d12Main(cmd *cobra.Command, args []string){
-	_ = cmd.Help()
+func (d *d12Solver) Parse(inputStr string) (any, error) {
+	logger.Debug("Parsing gardens")
+	return ParseGarden(inputStr)
 }
 
-func d12p1Main(cmd *cobra.Command, args []string) {
-	inputStr, err := loadInput(cmd, args)
-	MaybeDie(err)
-
-	slog.Debug("Parsing gardens")
-	garden, err := ParseGarden(inputStr)
-	MaybeDie(err)
+func (d *d12Solver) Part1(ctx context.Context, input any) (any, error) {
+	garden := input.(*Garden)
 
-	slog.Debug("Finding regions")
+	logger.Debug("Finding regions")
 	garden.FindRegions()
 
-	slog.Debug("Computing price")
+	logger.Debug("Computing price")
 	price := garden.Price(false)
+	AddExtra(ctx, "regions", garden.Summarize(false))
 
-	slog.Debug("Results:", "GardenPrice", price)
-	fmt.Printf("%v\n", price)
+	logger.Debug("Results:", "GardenPrice", price)
+	return price, nil
 }
 
-func d12p2Main(cmd *cobra.Command, args []string) {
-	inputStr, err := loadInput(cmd, args)
-	MaybeDie(err)
+func (d *d12Solver) Part2(ctx context.Context, input any) (any, error) {
+	garden := input.(*Garden)
 
-	slog.Debug("Parsing gardens")
-	garden, err := ParseGarden(inputStr)
-	MaybeDie(err)
-
-	slog.Debug("Finding regions")
+	logger.Debug("Finding regions")
 	garden.FindRegions()
 
-	slog.Debug("Computing price")
+	logger.Debug("Computing price")
 	price := garden.Price(true)
+	AddExtra(ctx, "regions", garden.Summarize(true))
 
-	slog.Debug("Results:", "GardenPrice", price)
-	fmt.Printf("%v\n", price)
+	logger.Debug("Results:", "GardenPrice", price)
+	return price, nil
 }
 
 type Garden struct {
@@ -97,11 +70,12 @@ func ParseGarden(inputStr string) (*Garden, error) {
 	}
 
 	for i, line := range lines {
+		line = strings.TrimSpace(line)
 		if gardens.Size.W == 0 {
 			gardens.Size.W = len(line)
 			gardens.Plots = make([]Plot, gardens.Size.Area())
 		} else if gardens.Size.W != len(line) {
-			return nil, fmt.Errorf("Line length didn't match at index %v", i)
+			return nil, parseErr(i, min(gardens.Size.W, len(line)), line, "Expected %v plots like the first line, found %v", gardens.Size.W, len(line))
 		}
 
 		for j, rn := range line {
@@ -127,36 +101,34 @@ var rose = [4]util.Vector{
 }
 
 func (grd *Garden) FindRegions() {
-	visited := util.MakeSet[Plot]()
+	plots := util.MakeUnionFind[util.Point]()
 	for _, plot := range grd.Plots {
-		if visited.Has(plot) {
-			continue
+		plots.Add(plot.Loc)
+		for _, dir := range rose[1:3] {
+			idx, err := grd.Size.Idx(plot.Loc.Add(dir))
+			if err != nil {
+				continue
+			}
+			if o := grd.Plots[idx]; plot.Label == o.Label {
+				plots.Union(plot.Loc, o.Loc)
+			}
 		}
+	}
 
-		nbors := util.MakeSet(plot)
-		region := util.MakeSet[util.Point]()
-
-		for !nbors.Empty() {
-			p := nbors.Pop()
-			visited.Add(p)
-			region.Add(p.Loc)
-
-			for _, dir := range rose {
-				idx, err := grd.Size.Idx(p.Loc.Add(dir))
-				if err != nil {
-					continue
-				}
-				o := grd.Plots[idx]
-				if p.Label == o.Label {
-					if !visited.Has(o) {
-						nbors.Add(o)
-					}
-				}
-			}
+	// Regions are listed in the order their first plot shows up
+	grd.Regions = []util.Set[util.Point]{}
+	regionIdx := make(map[util.Point]int)
+	for _, plot := range grd.Plots {
+		root := plots.Find(plot.Loc)
+		i, ok := regionIdx[root]
+		if !ok {
+			i = len(grd.Regions)
+			regionIdx[root] = i
+			grd.Regions = append(grd.Regions, util.MakeSet[util.Point]())
 		}
-		slog.Debug("Found region", "r", region)
-		grd.Regions = append(grd.Regions, region)
+		grd.Regions[i].Add(plot.Loc)
 	}
+	logger.Debug("Found regions", "count", len(grd.Regions))
 }
 
 func PriceRegion(region util.Set[util.Point], discount bool) (price int) {
@@ -184,6 +156,32 @@ func PriceRegion(region util.Set[util.Point], discount bool) (price int) {
 	return fences * area
 }
 
+type RegionSummary struct {
+	Label  string `json:"label"`
+	Area   int    `json:"area"`
+	Fences int    `json:"fences"`
+	Price  int    `json:"price"`
+}
+
+func (grd *Garden) Summarize(discount bool) []RegionSummary {
+	sums := make([]RegionSummary, 0, len(grd.Regions))
+	for _, region := range grd.Regions {
+		pt := region.First()
+		idx, err := grd.Size.Idx(pt)
+		if err != nil {
+			continue
+		}
+		price := PriceRegion(region, discount)
+		sums = append(sums, RegionSummary{
+			Label:  string(grd.Plots[idx].Label),
+			Area:   region.Size(),
+			Fences: price / region.Size(),
+			Price:  price,
+		})
+	}
+	return sums
+}
+
 func (grd *Garden) Price(discount bool) (price int) {
 	for _, region := range grd.Regions {
 		price += PriceRegion(region, discount)
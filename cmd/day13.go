@@ -1,79 +1,52 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
-	"log/slog"
 	"math"
 	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/dusktreader/advent-of-code-2024/util"
-	"github.com/spf13/cobra"
 )
 
 func init() {
-	rootCmd.AddCommand(d13Cmd)
-	d13Cmd.PersistentFlags().StringP("input-file", "i", "", "Get input from a file instead of stdin")
-	d13Cmd.AddCommand(d13p1Cmd)
-	d13Cmd.AddCommand(d13p2Cmd)
+	Register(&d13Solver{})
 }
 
-var d13Cmd = &cobra.Command{
-	Use:   "day13",
-	Short: "Day 13 Solutions",
-	Long:  "The solutions for day 13 of Advent of Code 2025",
-	Run:   d13Main,
-}
-
-var d13p1Cmd = &cobra.Command{
-	Use:   "part1",
-	Short: "Day 13, 1 Solution",
-	Long:  "The solution for day 13, part 1 of Advent of Code 2025",
-	Run:   d13p1Main,
-}
+type d13Solver struct{}
 
-var d13p2Cmd = &cobra.Command{
-	Use:   "part2",
-	Short: "Day 13, 2 Solution",
-	Long:  "The solution for day 13, part 2 of Advent of Code 2025",
-	Run:   d13p2Main,
+func (d *d13Solver) Day() int {
+	return 13
 }
 
-func d13Main(cmd *cobra.Command, args []string){
-	_ = cmd.Help()
+func (d *d13Solver) Parse(inputStr string) (any, error) {
+	logger.Debug("Parsing buttons")
+	return ParseButtons(inputStr)
 }
 
-func d13p1Main(cmd *cobra.Command, args []string) {
-	inputStr, err := loadInput(cmd, args)
-	MaybeDie(err)
-
-	slog.Debug("Parsing buttons")
-	buttons, err := ParseButtons(inputStr)
-	MaybeDie(err)
+func (d *d13Solver) Part1(ctx context.Context, input any) (any, error) {
+	buttons := input.([]Button)
 
-	slog.Debug("Counting tokens")
+	logger.Debug("Counting tokens")
 	count := CountTokens(buttons)
 
-	slog.Debug("Results:", "TokenCount", count)
-	fmt.Printf("%v\n", count)
+	logger.Debug("Results:", "TokenCount", count)
+	return count, nil
 }
 
-func d13p2Main(cmd *cobra.Command, args []string) {
-	inputStr, err := loadInput(cmd, args)
-	MaybeDie(err)
+func (d *d13Solver) Part2(ctx context.Context, input any) (any, error) {
+	buttons := input.([]Button)
 
-	slog.Debug("Parsing buttons")
-	buttons, err := ParseButtons(inputStr)
-	MaybeDie(err)
-
-	slog.Debug("Moving prize")
+	logger.Debug("Moving prize")
 	MovePrize(&buttons)
 
-	slog.Debug("Counting tokens")
+	logger.Debug("Counting tokens")
 	count := CountTokens(buttons)
 
-	slog.Debug("Results:", "TokenCount", count)
-	fmt.Printf("%v\n", count)
+	logger.Debug("Results:", "TokenCount", count)
+	return count, nil
 }
 
 type Button struct {
@@ -91,11 +64,11 @@ func (b Button) CompCounts() (t int, u int, err error) {
 
 	o := util.MakePoint(0, 0)
 	v := b.Prize.Diff(o)
-	slog.Debug("Diff", "V", v)
+	logger.Debug("Diff", "V", v)
 
 	t = v.Cross(b.B.Neg()) / det
 	u = v.Cross(b.A) / det
-	slog.Debug("t, u", "t", t, "u", u)
+	logger.Debug("t, u", "t", t, "u", u)
 
 	if t < 0 || u < 0 {
 		err = fmt.Errorf("Rays do not intersect")
@@ -113,7 +86,7 @@ func (b Button) CompCounts() (t int, u int, err error) {
 func (b Button) Count() int {
 	t, u, err := b.CompCounts()
 	if err != nil {
-		slog.Debug("Error computing counts", "Error", err)
+		logger.Debug("Error computing counts", "Error", err)
 		return math.MaxInt
 	}
 
@@ -137,34 +110,62 @@ func MovePrize(buttons *[]Button) {
 	}
 }
 
+var buttonRes = [3]*regexp.Regexp{
+	regexp.MustCompile(`^Button\s+A:\s+X\+(\d+),\s+Y\+(\d+)$`),
+	regexp.MustCompile(`^Button\s+B:\s+X\+(\d+),\s+Y\+(\d+)$`),
+	regexp.MustCompile(`^Prize:\s+X=(\d+),\s+Y=(\d+)$`),
+}
+
+var buttonForms = [3]string{
+	"Button A: X+<n>, Y+<n>",
+	"Button B: X+<n>, Y+<n>",
+	"Prize: X=<n>, Y=<n>",
+}
+
 func ParseButtons(inputStr string) ([]Button, error) {
-	re, err := regexp.Compile(
-		`Button\s+A:\s+X\+(\d+),\s+Y\+(\d+)\s+Button\s+B:\s+X\+(\d+),\s+Y\+(\d+)\s+Prize:\s+X=(\d+),\s+Y=(\d+)`,
-	)
-	if err != nil {
-		return nil, util.ReErr(err, "Couldn't compile regex")
-	}
+	inputStr = strings.TrimSpace(inputStr)
+	lines := strings.Split(inputStr, "\n")
 
 	buttons := make([]Button, 0)
+	ints := [6]int{}
+	k := 0
+
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			if k != 0 {
+				return nil, parseErr(i, -1, line, "Expected %q", buttonForms[k])
+			}
+			continue
+		}
 
-	for _, match := range re.FindAllStringSubmatch(inputStr, -1) {
-		ints := [6]int{}
-		for i := 0; i < 6; i++ {
-			val, err := strconv.Atoi(match[i + 1])
+		loc := buttonRes[k].FindStringSubmatchIndex(line)
+		if loc == nil {
+			return nil, parseErr(i, -1, line, "Expected %q", buttonForms[k])
+		}
+		for m := 1; m <= 2; m++ {
+			token := line[loc[m * 2]:loc[m * 2 + 1]]
+			val, err := strconv.Atoi(token)
 			if err != nil {
-				return nil, util.ReErr(err, "Failed to convert int: %v", match[i + 1])
+				return nil, parseErr(i, loc[m * 2], line, "Number %q is out of range", token)
 			}
-			ints[i] = val
+			ints[k * 2 + m - 1] = val
 		}
 
-		buttons = append(
-			buttons,
-			Button{
-				A: util.MakeVector(ints[0], ints[1]),
-				B: util.MakeVector(ints[2], ints[3]),
-				Prize: util.MakePoint(ints[4], ints[5]),
-			},
-		)
+		k = (k + 1) % 3
+		if k == 0 {
+			buttons = append(
+				buttons,
+				Button{
+					A: util.MakeVector(ints[0], ints[1]),
+					B: util.MakeVector(ints[2], ints[3]),
+					Prize: util.MakePoint(ints[4], ints[5]),
+				},
+			)
+		}
+	}
+	if k != 0 {
+		return nil, parseErr(len(lines), -1, "", "Expected %q", buttonForms[k])
 	}
 	return buttons, nil
 }
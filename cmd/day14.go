@@ -1,98 +1,92 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
-	"log/slog"
+	"math"
+	"os"
 	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/dusktreader/advent-of-code-2024/util"
 	"github.com/spf13/cobra"
 )
 
 func init() {
-	rootCmd.AddCommand(d14Cmd)
-	d14Cmd.PersistentFlags().StringP("input-file", "i", "", "Get input from a file instead of stdin")
-	d14Cmd.PersistentFlags().BoolP("small", "s", false, "Use smaller space")
-	d14Cmd.PersistentFlags().BoolP("visualize", "V", false, "Visualize space")
-	d14Cmd.AddCommand(d14p1Cmd)
-	d14Cmd.AddCommand(d14p2Cmd)
+	Register(&d14Solver{})
 }
 
-var d14Cmd = &cobra.Command{
-	Use:   "day14",
-	Short: "Day 14 Solutions",
-	Long:  "The solutions for day 14 of Advent of Code 2025",
-	Run:   d14Main,
+type d14Solver struct {
+	small     bool
+	visualize bool
 }
 
-var d14p1Cmd = &cobra.Command{
-	Use:   "part1",
-	Short: "Day 14, 1 Solution",
-	Long:  "The solution for day 14, part 1 of Advent of Code 2025",
-	Run:   d14p1Main,
+func (d *d14Solver) Day() int {
+	return 14
 }
 
-var d14p2Cmd = &cobra.Command{
-	Use:   "part2",
-	Short: "Day 14, 2 Solution",
-	Long:  "The solution for day 14, part 2 of Advent of Code 2025",
-	Run:   d14p2Main,
+func (d *d14Solver) Flags(part int, cmd *cobra.Command) {
+	if part == 0 {
+		cmd.PersistentFlags().BoolVarP(&d.small, "small", "s", false, "Use smaller space")
+		cmd.PersistentFlags().BoolVarP(&d.visualize, "visualize", "V", false, "Visualize space on stderr")
+	}
 }
 
-func d14Main(cmd *cobra.Command, args []string){
-	_ = cmd.Help()
+func (d *d14Solver) Parse(inputStr string) (any, error) {
+	logger.Debug("Parsing robots")
+	return ParseRobots(inputStr)
 }
 
-func d14p1Main(cmd *cobra.Command, args []string) {
-	inputStr, err := loadInput(cmd, args)
-	MaybeDie(err)
-
-	slog.Debug("Parsing robots")
-	ps, err := ParseRobots(inputStr)
-	MaybeDie(err)
+func (d *d14Solver) Part1(ctx context.Context, input any) (any, error) {
+	ps := input.(*PissSpace)
 
-	small, err := cmd.Flags().GetBool("small")
-	MaybeDie(err)
-
-	if small {
+	if d.small {
 		ps.Size = util.Size{W: 11, H: 7}
 	} else {
 		ps.Size = util.Size{W: 101, H: 103}
 	}
 
-	slog.Debug("Moving robots")
+	logger.Debug("Moving robots")
 	ps.MoveRobots(100)
 
-	visualize, err := cmd.Flags().GetBool("visualize")
-	MaybeDie(err)
-
-	if visualize {
-		fmt.Printf("%v\n", ps.Viz())
+	if d.visualize {
+		fmt.Fprintf(os.Stderr, "%v\n", ps.Viz())
 	}
 
-	slog.Debug("Computing safety")
+	logger.Debug("Computing safety")
 	safety := ps.ComputeSafety()
 
-	slog.Debug("Results:", "Safety", safety)
-	fmt.Printf("%v\n", safety)
+	logger.Debug("Results:", "Safety", safety)
+	return safety, nil
 }
 
-func d14p2Main(cmd *cobra.Command, args []string) {
-	inputStr, err := loadInput(cmd, args)
-	MaybeDie(err)
-
-	slog.Debug("Parsing robots")
-	ps, err := ParseRobots(inputStr)
-	MaybeDie(err)
+// The tree picture clusters the robots into one quadrant, so the frame with the lowest safety is the best
+// guess. Unlike dumping every frame to be checked by eye, that gives an answer all and verify can check
+func (d *d14Solver) Part2(ctx context.Context, input any) (any, error) {
+	ps := input.(*PissSpace)
 
 	ps.Size = util.Size{W: 101, H: 103}
 
+	best, bestSafety := 0, math.MaxInt
 	for i := range 10_000 {
-		fmt.Printf("\nIteration %v\n", i)
-		fmt.Printf("%v\n\n\n", ps.Viz())
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if d.visualize {
+			fmt.Fprintf(os.Stderr, "\nIteration %v\n", i)
+			fmt.Fprintf(os.Stderr, "%v\n\n\n", ps.Viz())
+		}
+
+		safety := ps.ComputeSafety()
+		if safety < bestSafety {
+			best, bestSafety = i, safety
+		}
 		ps.MoveRobots(1)
 	}
+
+	logger.Debug("Results:", "Iteration", best, "Safety", bestSafety)
+	return best, nil
 }
 
 type Robot struct {
@@ -129,14 +123,14 @@ func (ps *PissSpace) Viz() string {
 
 	counter := util.MakeCounter[util.Point]()
 	for _, r := range ps.Robots {
-		slog.Debug("Counting robot at:", "Pos", r.Pos)
+		logger.Debug("Counting robot at:", "Pos", r.Pos)
 		counter.Incr(r.Pos)
 	}
 
 	for p, c := range counter.Iter() {
 		v, err := util.ItoR(c)
 		if err != nil {
-			slog.Error("Couldn't convert int to rune", "Error", err)
+			logger.Error("Couldn't convert int to rune", "Error", err)
 			continue
 		}
 		grid[p.I][p.J] = v
@@ -170,7 +164,7 @@ func (ps *PissSpace) ComputeSafety() int {
 		O: util.MakePoint(ps.Size.H / 2 + 1, ps.Size.W / 2 + 1),
 		Sz: ps.Size.Div(2),
 	}
-	slog.Debug("Quads", "Quads", quads)
+	logger.Debug("Quads", "Quads", quads)
 
 	prod := 1
 	for _, q := range quads {
@@ -186,27 +180,30 @@ func (ps *PissSpace) ComputeSafety() int {
 	return prod
 }
 
-func ParseRobots(inputStr string) (*PissSpace, error) {
-	re, err := regexp.Compile(
-		`p=(\d+),(\d+) v=(-?\d+),(-?\d+)`,
-	)
-	if err != nil {
-		return nil, util.ReErr(err, "Couldn't compile regex")
-	}
+var robotRe = regexp.MustCompile(`^p=(\d+),(\d+) v=(-?\d+),(-?\d+)$`)
 
+func ParseRobots(inputStr string) (*PissSpace, error) {
 	pisser := PissSpace{
 		Size: util.Size{W: 0, H: 0},
 		Robots: make([]Robot, 0),
 	}
 
-	for _, match := range re.FindAllStringSubmatch(inputStr, -1) {
+	inputStr = strings.TrimSpace(inputStr)
+	for i, line := range strings.Split(inputStr, "\n") {
+		line = strings.TrimSpace(line)
+		loc := robotRe.FindStringSubmatchIndex(line)
+		if loc == nil {
+			return nil, parseErr(i, -1, line, "Expected a robot like \"p=<x>,<y> v=<dx>,<dy>\"")
+		}
+
 		ints := [4]int{}
-		for i := 0; i < 4; i++ {
-			val, err := strconv.Atoi(match[i + 1])
+		for k := 1; k <= 4; k++ {
+			token := line[loc[k * 2]:loc[k * 2 + 1]]
+			val, err := strconv.Atoi(token)
 			if err != nil {
-				return nil, util.ReErr(err, "Failed to convert int: %v", match[i + 1])
+				return nil, parseErr(i, loc[k * 2], line, "Number %q is out of range", token)
 			}
-			ints[i] = val
+			ints[k - 1] = val
 		}
 
 		rob := Robot{
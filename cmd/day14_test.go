@@ -1,7 +1,10 @@
 package cmd_test
 
 import (
+	"context"
+	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/dusktreader/advent-of-code-2024/cmd"
@@ -30,3 +33,23 @@ func TestParseRobots(t *testing.T) {
 		t.Fatalf("Didn't match: wanted %+v, got %+v", want, got)
 	}
 }
+
+func TestTreeFrame(t *testing.T) {
+	// Every robot is sent through the same spot at the same step, which clears out three of the quadrants
+	want := 50
+	mod := func(a, b int) int { return (a % b + b) % b }
+	var sb strings.Builder
+	for i := range 200 {
+		vx, vy := i * 37 % 101 - 50, i * 53 % 103 - 51
+		px, py := mod(10 - want * vx, 101), mod(10 - want * vy, 103)
+		fmt.Fprintf(&sb, "p=%v,%v v=%v,%v\n", px, py, vx, vy)
+	}
+
+	s, err := cmd.GetSolver(14)
+	util.Unexpect(t, err)
+	got, err := cmd.Solve(context.Background(), s, 2, sb.String())
+	util.Unexpect(t, err)
+	if got != want {
+		t.Errorf("Wrong frame: want %v, got %v", want, got)
+	}
+}
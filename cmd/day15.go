@@ -1,8 +1,8 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
-	"log/slog"
 	"os"
 	"os/exec"
 	"strings"
@@ -13,108 +13,87 @@ import (
 )
 
 func init() {
-	rootCmd.AddCommand(d15Cmd)
-	d15Cmd.PersistentFlags().StringP("input-file", "i", "", "Get input from a file instead of stdin")
-	d15Cmd.PersistentFlags().BoolP("small", "s", false, "Use smaller space")
-	d15Cmd.PersistentFlags().CountP("visualize", "V", "Visualize space. Pass multiple to visualize more")
-	d15Cmd.AddCommand(d15p1Cmd)
-	d15Cmd.AddCommand(d15p2Cmd)
-	d15p2Cmd.Flags().IntP("stretch-horizontal", "F", 2, "Horizontal stretch factor.")
-	d15p2Cmd.Flags().IntP("stretch-vertical", "f", 1, "Vertical stretch factor.")
+	Register(&d15Solver{wf: 2, hf: 1})
 }
 
-var d15Cmd = &cobra.Command{
-	Use:   "day15",
-	Short: "Day 15 Solutions",
-	Long:  "The solutions for day 15 of Advent of Code 2025",
-	Run:   d15Main,
+type d15Solver struct {
+	viz int
+	wf  int
+	hf  int
 }
 
-var d15p1Cmd = &cobra.Command{
-	Use:   "part1",
-	Short: "Day 15, 1 Solution",
-	Long:  "The solution for day 15, part 1 of Advent of Code 2025",
-	Run:   d15p1Main,
+func (d *d15Solver) Day() int {
+	return 15
 }
 
-var d15p2Cmd = &cobra.Command{
-	Use:   "part2",
-	Short: "Day 15, 2 Solution",
-	Long:  "The solution for day 15, part 2 of Advent of Code 2025",
-	Run:   d15p2Main,
+func (d *d15Solver) Flags(part int, cmd *cobra.Command) {
+	switch part {
+	case 0:
+		cmd.PersistentFlags().CountVarP(&d.viz, "visualize", "V", "Visualize space on stderr. Pass multiple to visualize more")
+	case 2:
+		cmd.Flags().IntVarP(&d.wf, "stretch-horizontal", "F", 2, "Horizontal stretch factor.")
+		cmd.Flags().IntVarP(&d.hf, "stretch-vertical", "f", 1, "Vertical stretch factor.")
+	}
 }
 
-func d15Main(cmd *cobra.Command, args []string){
-	_ = cmd.Help()
+func (d *d15Solver) Parse(inputStr string) (any, error) {
+	logger.Debug("Parsing warehouse")
+	return ParseWarehouse(inputStr)
 }
 
-func getStretch(cmd *cobra.Command, args []string) (int, int) {
-	wf, err := cmd.Flags().GetInt("stretch-horizontal")
-	MaybeDie(err)
-	if wf < 1 {
-		Die("Invalid horizontal stretch factor: %v", wf)
-	}
+func (d *d15Solver) Part1(ctx context.Context, input any) (any, error) {
+	wh := input.(*Warehouse)
 
-	hf, err := cmd.Flags().GetInt("stretch-vertical")
-	MaybeDie(err)
-	if hf < 1 {
-		Die("Invalid vertical stretch factor: %v", hf)
+	logger.Debug("Moving robot")
+	err := wh.MoveAll(ctx, d.viz)
+	if err != nil {
+		return nil, err
 	}
-	return wf, hf
-}
-
-func d15p1Main(cmd *cobra.Command, args []string) {
-	viz := getViz(cmd, args)
 
-	inputStr, err := loadInput(cmd, args)
-	MaybeDie(err)
-
-	slog.Debug("Parsing warehouse")
-	wh, err := ParseWarehouse(inputStr)
-	MaybeDie(err)
-
-	slog.Debug("Moving robot")
-	wh.MoveAll(viz)
-
-	if viz > 0 {
-		slog.Debug("Visualizing final warehouse")
-		fmt.Printf("%v\n", wh)
+	if d.viz > 0 {
+		logger.Debug("Visualizing final warehouse")
+		fmt.Fprintf(os.Stderr, "%v\n", wh)
 	}
 
-	slog.Debug("Box count:", "Boxes", wh.Boxes.Size())
+	logger.Debug("Box count:", "Boxes", wh.Boxes.Size())
 	gps := wh.GPS()
 
-	slog.Debug("Results:", "GPS", gps)
-	fmt.Printf("%v\n", gps)
+	logger.Debug("Results:", "GPS", gps)
+	return gps, nil
 }
 
-func d15p2Main(cmd *cobra.Command, args []string) {
-	wf, hf := getStretch(cmd, args)
-	viz := getViz(cmd, args)
+func (d *d15Solver) Part2(ctx context.Context, input any) (any, error) {
+	wh := input.(*Warehouse)
 
-	inputStr, err := loadInput(cmd, args)
-	MaybeDie(err)
-
-	slog.Debug("Parsing warehouse")
-	wh, err := ParseWarehouse(inputStr)
-	MaybeDie(err)
+	if d.wf < 1 {
+		return nil, &FlagError{Flag: "stretch-horizontal", Err: fmt.Errorf("Invalid horizontal stretch factor: %v", d.wf)}
+	}
+	if d.hf < 1 {
+		return nil, &FlagError{Flag: "stretch-vertical", Err: fmt.Errorf("Invalid vertical stretch factor: %v", d.hf)}
+	}
 
-	slog.Debug("Stretching warehouse")
-	wh.Stretch(wf, hf)
+	logger.Debug("Stretching warehouse")
+	err := wh.Stretch(d.wf, d.hf)
+	if err != nil {
+		return nil, err
+	}
 
-	slog.Debug("Moving robot")
-	wh.MoveAll(viz)
+	logger.Debug("Moving robot")
+	err = wh.MoveAll(ctx, d.viz)
+	if err != nil {
+		return nil, err
+	}
 
-	if viz > 0 {
-		slog.Debug("Visualizing final warehouse")
-		fmt.Printf("%v\n", wh)
+	if d.viz > 0 {
+		logger.Debug("Visualizing final warehouse")
+		fmt.Fprintf(os.Stderr, "%v\n", wh)
 	}
 
-	slog.Debug("Box count:", "Boxes", wh.Boxes.Size())
+	logger.Debug("Box count:", "Boxes", wh.Boxes.Size())
 	gps := wh.GPS()
 
-	slog.Debug("Results:", "GPS", gps)
-	fmt.Printf("%v\n", gps)
+	logger.Debug("Results:", "GPS", gps)
+	return gps, nil
 }
 
 type Warehouse struct {
@@ -223,7 +202,7 @@ func (wh *Warehouse) MoveRobot() error {
 
 func Cls() {
 	cmd := exec.Command("clear")
-	cmd.Stdout = os.Stdout
+	cmd.Stdout = os.Stderr
 	cmd.Run()
 }
 
@@ -231,26 +210,30 @@ func Idle() {
 	time.Sleep(100_000_000 * time.Nanosecond)
 }
 
-func (wh *Warehouse) MoveAll(viz int) {
+func (wh *Warehouse) MoveAll(ctx context.Context, viz int) error {
 	if viz == 3 {
 		Cls()
-		fmt.Printf("%v\n\n", wh)
+		fmt.Fprintf(os.Stderr, "%v\n\n", wh)
 		Idle()
 		Cls()
 	}
 	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		err := wh.MoveRobot()
 		if err != nil {
 			break
 		}
 		if viz == 2 {
-			fmt.Printf("%v\n\n", wh)
+			fmt.Fprintf(os.Stderr, "%v\n\n", wh)
 		} else if viz == 3 {
-			fmt.Printf("%v\n\n", wh)
+			fmt.Fprintf(os.Stderr, "%v\n\n", wh)
 			Idle()
 			Cls()
 		}
 	}
+	return nil
 }
 
 func (wh *Warehouse) Boxed(pt util.Point) *util.Rect {
@@ -349,7 +332,7 @@ func ParseWarehouse(inputStr string) (*Warehouse, error) {
 
 	parsingMap := true
 	wh := Warehouse{
-		Sz: util.Size{W: len(lines[0]), H: 0},
+		Sz: util.Size{W: len(strings.TrimSpace(lines[0])), H: 0},
 		Walls: util.MakeSet[util.Point](),
 		Boxes: util.MakeSet[*util.Rect](),
 		Moves: *util.MakeQueue[util.Vector](),
@@ -357,12 +340,16 @@ func ParseWarehouse(inputStr string) (*Warehouse, error) {
 	}
 
 	for i, line := range lines {
+		line = strings.TrimSpace(line)
 		if line == "" {
 			parsingMap = false
 			continue
 		}
 
 		if parsingMap {
+			if len(line) != wh.Sz.W {
+				return nil, parseErr(i, min(wh.Sz.W, len(line)), line, "Expected %v cells like the first line, found %v", wh.Sz.W, len(line))
+			}
 			for j, rn := range line {
 				pt := util.MakePoint(i, j)
 				switch rn {
@@ -373,11 +360,14 @@ func ParseWarehouse(inputStr string) (*Warehouse, error) {
 					wh.Boxes.Add(&box)
 				case '@':
 					wh.Robot = pt
+				case '.':
+				default:
+					return nil, parseErr(i, j, line, "Unknown map cell %q", rn)
 				}
 			}
 			wh.Sz.H++
 		} else {
-			for _, rn := range line {
+			for j, rn := range line {
 				switch rn {
 				case '^':
 					wh.Moves.Push(util.NORTH)
@@ -388,7 +378,7 @@ func ParseWarehouse(inputStr string) (*Warehouse, error) {
 				case '<':
 					wh.Moves.Push(util.WEST)
 				default:
-					return nil, fmt.Errorf("Unknown direction in moves: %v", rn)
+					return nil, parseErr(i, j, line, "Unknown move %q, expected one of ^>v<", rn)
 				}
 			}
 		}
@@ -1,8 +1,10 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
-	"log/slog"
+	"iter"
+	"os"
 	"strings"
 
 	"github.com/dusktreader/advent-of-code-2024/graph"
@@ -11,57 +13,57 @@ import (
 )
 
 func init() {
-	rootCmd.AddCommand(d16Cmd)
-	d16Cmd.PersistentFlags().StringP("input-file", "i", "", "Get input from a file instead of stdin")
-	d16Cmd.PersistentFlags().CountP("visualize", "V", "Visualize maze. Pass multiple to visualize more")
-	d16Cmd.AddCommand(d16p1Cmd)
-	d16Cmd.AddCommand(d16p2Cmd)
+	Register(&d16Solver{})
 }
 
-var d16Cmd = &cobra.Command{
-	Use:   "day16",
-	Short: "Day 16 Solutions",
-	Long:  "The solutions for day 16 of Advent of Code 2025",
-	Run:   d16Main,
+type d16Solver struct {
+	viz int
 }
 
-var d16p1Cmd = &cobra.Command{
-	Use:   "part1",
-	Short: "Day 16, 1 Solution",
-	Long:  "The solution for day 16, part 1 of Advent of Code 2025",
-	Run:   d16p1Main,
+func (d *d16Solver) Day() int {
+	return 16
 }
 
-var d16p2Cmd = &cobra.Command{
-	Use:   "part2",
-	Short: "Day 16, 2 Solution",
-	Long:  "The solution for day 16, part 2 of Advent of Code 2025",
-	Run:   d16p2Main,
+func (d *d16Solver) Flags(part int, cmd *cobra.Command) {
+	if part == 0 {
+		cmd.PersistentFlags().CountVarP(&d.viz, "visualize", "V", "Visualize maze on stderr. Pass multiple to visualize more")
+	}
 }
 
-func d16Main(cmd *cobra.Command, args []string){
-	_ = cmd.Help()
+func (d *d16Solver) Parse(inputStr string) (any, error) {
+	logger.Debug("Parsing maze")
+	return ParseMaze(inputStr)
 }
 
-func d16p1Main(cmd *cobra.Command, args []string) {
-	//viz := getViz(cmd, args)
+func (d *d16Solver) Part1(ctx context.Context, input any) (any, error) {
+	mz := input.(*Maze)
 
-	inputStr, err := loadInput(cmd, args)
-	MaybeDie(err)
+	logger.Debug("Finding best paths")
+	err := mz.FindSeats(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	slog.Debug("Parsing maze")
-	mz, err := ParseMaze(inputStr)
-	MaybeDie(err)
+	logger.Debug("Results:", "Score", mz.Score)
+	return mz.Score, nil
+}
 
-	mz.Simplify()
+func (d *d16Solver) Part2(ctx context.Context, input any) (any, error) {
+	mz := input.(*Maze)
 
-	fmt.Printf("%v\n", mz)
+	logger.Debug("Finding best paths")
+	err := mz.FindSeats(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	// slog.Debug("Results:", "Score", mz.BestDeer.Score)
-	// fmt.Printf("%v\n", mz.BestDeer.Score)
-}
+	if d.viz > 0 {
+		logger.Debug("Visualizing best seats")
+		fmt.Fprintf(os.Stderr, "%v\n", mz)
+	}
 
-func d16p2Main(cmd *cobra.Command, args []string) {
+	logger.Debug("Results:", "Seats", mz.Seats.Size())
+	return mz.Seats.Size(), nil
 }
 
 type Maze struct {
@@ -71,6 +73,8 @@ type Maze struct {
 	End       util.Point
 	Graph     graph.Graph[util.Point]
 	Hilite    util.Point
+	Score     int
+	Seats     util.Set[util.Point]
 }
 
 func (mz *Maze) RenderPt(pt util.Point) rune {
@@ -82,6 +86,8 @@ func (mz *Maze) RenderPt(pt util.Point) rune {
 		return 'E'
 	} else if mz.Hilite == pt {
 		return 'X'
+	} else if mz.Seats.Has(pt) {
+		return 'O'
 	} else if mz.Graph.Has(pt) {
 		return 'o'
 	}
@@ -91,7 +97,7 @@ func (mz *Maze) RenderPt(pt util.Point) rune {
 
 func (mz *Maze) String() string {
 	var sb strings.Builder
-	//sb.WriteString(fmt.Sprintf("Best score: %v, Current score: %v\n", mz.BestDeer.Score, mz.Deer.Score))
+	sb.WriteString(fmt.Sprintf("Best score: %v, Seats: %v\n", mz.Score, mz.Seats.Size()))
 	for i := 0; i < mz.Size.H; i++ {
 		for j := 0; j < mz.Size.W; j++ {
 			pt := util.MakePoint(i, j)
@@ -105,97 +111,53 @@ func (mz *Maze) String() string {
 	return sb.String()
 }
 
-func (mz *Maze) Simplify() {
-	corners  := util.MakeSet[util.Point]()
-	straights := util.MakeSet[util.Point]()
-	deadEnds := util.MakeSet[util.Point]()
-	for node := range mz.Graph.Nodes().Iter() {
-		if node == mz.Start || node == mz.End {
-			continue
-		}
-
-		nbors := mz.Graph.Nbors(node)
-		if nbors.Size() == 1 {
-			deadEnds.Add(node)
-			continue
-		}
-
-		if nbors.Size() > 2 {
-			corners.Add(node)
-			continue
+// The deer's state is its position and facing. Stepping ahead costs 1 and turning costs 1000
+func (mz *Maze) DeerMoves(deer util.Ray) iter.Seq2[util.Ray, int] {
+	return func(yield func(util.Ray, int) bool) {
+		ahead := deer.O.Add(deer.V)
+		if mz.Graph.Has(ahead) && !yield(util.Ray{O: ahead, V: deer.V}, 1) {
+			return
 		}
-
-		a := nbors.Pop()
-		b := nbors.Pop()
-		if a.I != b.I && a.J != b.J {
-			corners.Add(node)
-			continue
+		if !yield(util.Ray{O: deer.O, V: deer.V.RotCW()}, 1000) {
+			return
 		}
-
-		straights.Add(node)
+		yield(util.Ray{O: deer.O, V: deer.V.RotCCW()}, 1000)
 	}
+}
 
-	for !corners.Empty() {
-		node := corners.Pop()
-		if node == mz.Start || node == mz.End {
-			continue
-		}
-
-		nbors := mz.Graph.Nbors(node)
-		for !nbors.Empty() {
-			a := nbors.Pop()
-			straights.Rem(a)
-			for b := range nbors.Iter() {
-				if a.I == b.I || a.J == b.J {
-					mz.Graph.AddEdge(a, b, 2)
-				} else {
-					mz.Graph.AddEdge(a, b, 1002)
-				}
-			}
-		}
-		mz.Graph.Rem(node)
+// Finds the best score and every tile that is on at least one of the best paths
+func (mz *Maze) FindSeats(ctx context.Context) error {
+	start := util.Ray{O: mz.Start, V: util.EAST}
+	atEnd := func(deer util.Ray) bool { return deer.O == mz.End }
+	score, best, err := graph.Implicit[util.Ray](mz.DeerMoves).ShortestPathDAG(ctx, start, atEnd)
+	if ctx.Err() != nil {
+		return err
+	} else if err != nil {
+		return &NoSolutionError{Msg: "The deer can't reach the end", Err: err}
 	}
 
-	for !deadEnds.Empty() {
-		node := deadEnds.Pop()
-		if node == mz.Start || node == mz.End {
-			continue
-		}
-
-		nbors := mz.Graph.Nbors(node)
-		nbor := nbors.Pop()
-		if mz.Graph.Nbors(nbor).Size() == 2 {
-			deadEnds.Add(nbor)
-		}
-		mz.Graph.Rem(node)
+	mz.Score = score
+	mz.Seats = util.MakeSet[util.Point]()
+	for deer := range best.Nodes().Iter() {
+		mz.Seats.Add(deer.O)
 	}
+	return nil
+}
 
-	for !straights.Empty() {
-		b := straights.Pop()
-		if !mz.Graph.Has(b) {
-			continue
-		}
-
-		if b == mz.Start || b == mz.End {
-			continue
-		}
-
-		nbors := mz.Graph.Nbors(b)
-		a := nbors.Pop()
-		c := nbors.Pop()
-		ac, ok := mz.Graph.Edge(a, b)
-		if !ok {
-			panic("No edge from a to b. This should not happen")
-		}
-
-		bc, ok := mz.Graph.Edge(b, c)
-		if !ok {
-			panic("No edge from b to c. This should not happen")
-		}
+// Collapses the maze down to its junctions, dropping dead ends. Turns aren't priced in, so this suits
+// questions about distance rather than score
+func (mz *Maze) Simplify() (*graph.Contraction[util.Point], error) {
+	return mz.Graph.Contract(util.MakeSet(mz.Start, mz.End))
+}
 
-		mz.Graph.AddEdge(a, c, ac.Wt + bc.Wt)
-		mz.Graph.Rem(b)
+// Finds the cells the deer has to pass through on every route from the start to the end, in the order it
+// reaches them. Walling off any one of them would leave the deer stuck
+func (mz *Maze) Chokepoints(ctx context.Context) ([]util.Point, error) {
+	cells, _, err := mz.Graph.Chokepoints(ctx, mz.Start, mz.End)
+	if err != nil {
+		return nil, &NoSolutionError{Msg: "The deer can't reach the end", Err: err}
 	}
+	return cells, nil
 }
 
 func ParseMaze(inputStr string) (*Maze, error) {
@@ -203,43 +165,54 @@ func ParseMaze(inputStr string) (*Maze, error) {
 	lines := strings.Split(inputStr, "\n")
 
 	if len(lines) < 1 {
-		return nil, fmt.Errorf("No lines in input")
+		return nil, &ParseError{Msg: "No lines in input"}
 	}
 
 	mz := Maze{
-		Size:      util.Size{W: len(lines[0]), H: len(lines)},
+		Size:      util.Size{W: len(strings.TrimSpace(lines[0])), H: len(lines)},
 		Walls:     util.MakeSet[util.Point](),
-		Graph:     *graph.MakeGraph[util.Point](false),
+		Seats:     util.MakeSet[util.Point](),
 	}
 
+	cells := make([]rune, 0, mz.Size.Area())
 	for i, line := range lines {
+		line = strings.TrimSpace(line)
 		if len(line) != mz.Size.W {
-			return nil, fmt.Errorf("Line %d is not the same length", i)
+			return nil, parseErr(i, min(mz.Size.W, len(line)), line, "Expected %v cells like the first line, found %v", mz.Size.W, len(line))
 		}
 		for j, rn := range line {
 			pt := util.MakePoint(i, j)
 			if rn == '#' {
 				mz.Walls.Add(pt)
-				continue
 			} else if rn == 'S' {
 				mz.Start = pt
 			} else if rn == 'E' {
 				mz.End = pt
+			} else if rn != '.' {
+				return nil, parseErr(i, j, line, "Unknown maze cell %q", rn)
 			}
+			cells = append(cells, rn)
+		}
+	}
 
-			mz.Graph.Add(pt)
+	grid, err := util.MakeGrid(mz.Size, cells)
+	if err != nil {
+		return nil, err
+	}
+	g, err := graph.FromGrid(grid, graph.NEIGHBORS4, false, func(from rune, to rune) (int, bool) {
+		return 1, from != '#' && to != '#'
+	})
+	if err != nil {
+		return nil, err
+	}
 
-			nPt := pt.Add(util.NORTH)
-			if mz.Graph.Has(nPt) {
-				mz.Graph.AddEdge(pt, nPt)
-			}
-			wPt := pt.Add(util.WEST)
-			if mz.Graph.Has(wPt) {
-				mz.Graph.AddEdge(pt, wPt)
-			}
+	// Open cells walled in on every side still belong in the maze
+	for pt := range mz.Size.Iter() {
+		if !mz.Walls.Has(pt) {
+			g.Add(pt)
 		}
 	}
+	mz.Graph = *g
 
 	return &mz, nil
 }
-
@@ -0,0 +1,128 @@
+package cmd_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/dusktreader/advent-of-code-2024/cmd"
+	"github.com/dusktreader/advent-of-code-2024/util"
+)
+
+func TestFindSeats(t *testing.T) {
+	inputStr := `
+		#################
+		#...#...#...#..E#
+		#.#.#.#.#.#.#.#.#
+		#.#.#.#...#...#.#
+		#.#.#.#.###.#.#.#
+		#...#.#.#.....#.#
+		#.#.#.#.#.#####.#
+		#.#...#.#.#.....#
+		#.#.#####.#.###.#
+		#.#.#.......#...#
+		#.#.###.#####.###
+		#.#.#...#.....#.#
+		#.#.#.#####.###.#
+		#.#.#.........#.#
+		#.#.#.#########.#
+		#S#.............#
+		#################
+	`
+
+	mz, err := cmd.ParseMaze(inputStr)
+	util.Unexpect(t, err)
+
+	err = mz.FindSeats(context.Background())
+	util.Unexpect(t, err)
+
+	want := 11048
+	got  := mz.Score
+	if want != got {
+		t.Errorf("Wrong score: wanted %v, got %v", want, got)
+	}
+
+	want = 64
+	got  = mz.Seats.Size()
+	if want != got {
+		t.Errorf("Wrong seat count: wanted %v, got %v", want, got)
+	}
+}
+
+func TestSimplify(t *testing.T) {
+	inputStr := `
+		###############
+		#.......#....E#
+		#.#.###.#.###.#
+		#.....#.#...#.#
+		#.###.#####.#.#
+		#.#.#.......#.#
+		#.#.#####.###.#
+		#...........#.#
+		###.#.#####.#.#
+		#...#.....#.#.#
+		#.#.#.###.#.#.#
+		#.....#...#.#.#
+		#.###.#.#.#.#.#
+		#S..#.....#...#
+		###############
+	`
+
+	mz, err := cmd.ParseMaze(inputStr)
+	util.Unexpect(t, err)
+
+	c, err := mz.Simplify()
+	util.Unexpect(t, err)
+
+	if !c.Graph.Has(mz.Start) || !c.Graph.Has(mz.End) {
+		t.Fatalf("Simplify shouldn't remove the start or end")
+	}
+	if c.Graph.Nodes().Size() >= mz.Graph.Nodes().Size() / 2 {
+		t.Errorf("Expected far fewer nodes after simplifying, got %v of %v", c.Graph.Nodes().Size(), mz.Graph.Nodes().Size())
+	}
+
+	want, _, err := mz.Graph.ShortestPaths(context.Background(), mz.Start, mz.End)
+	util.Unexpect(t, err)
+	got, _, err := c.Graph.ShortestPaths(context.Background(), mz.Start, mz.End)
+	util.Unexpect(t, err)
+	if want != got {
+		t.Errorf("Simplify changed the distance: wanted %v, got %v", want, got)
+	}
+}
+
+func TestChokepoints(t *testing.T) {
+	inputStr := `
+		#########
+		#S..#..E#
+		#.#...#.#
+		#...#...#
+		#########
+	`
+
+	mz, err := cmd.ParseMaze(inputStr)
+	util.Unexpect(t, err)
+
+	got, err := mz.Chokepoints(context.Background())
+	util.Unexpect(t, err)
+
+	want := []util.Point{util.MakePoint(2, 3), util.MakePoint(2, 4), util.MakePoint(2, 5)}
+	if !slices.Equal(want, got) {
+		t.Errorf("Wrong chokepoints: wanted %v, got %v", want, got)
+	}
+}
+
+func TestFindSeatsFail(t *testing.T) {
+	inputStr := `
+		#####
+		#S#E#
+		#####
+	`
+
+	mz, err := cmd.ParseMaze(inputStr)
+	util.Unexpect(t, err)
+
+	err = mz.FindSeats(context.Background())
+	if err == nil {
+		t.Errorf("Expected an error for an unreachable end")
+	}
+}
@@ -0,0 +1,305 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	EXIT_OK          = 0
+	EXIT_ERROR       = 1
+	EXIT_FLAG        = 2
+	EXIT_INPUT       = 3
+	EXIT_PARSE       = 4
+	EXIT_NO_SOLUTION = 5
+	EXIT_TIMEOUT     = 6
+)
+
+type InputError struct {
+	Path string
+	Err  error
+}
+
+func (e *InputError) Error() string {
+	src := "stdin"
+	if e.Path != "" && e.Path != stdinPath {
+		src = e.Path
+	}
+	return fmt.Sprintf("Couldn't read input from %v: %v", src, e.Err)
+}
+
+func (e *InputError) Unwrap() error {
+	return e.Err
+}
+
+// Line and Col are 1-based. Zero means the position isn't known
+type ParseError struct {
+	File    string
+	Line    int
+	Col     int
+	Snippet string
+	Msg     string
+	Err     error
+}
+
+// Builds a ParseError from the 0-based line index and byte offset the parsers loop over.
+// A negative offset marks the whole line as bad
+func parseErr(i int, j int, snippet string, format string, args ...any) *ParseError {
+	col := 0
+	if j >= 0 && j <= len(snippet) {
+		col = utf8.RuneCountInString(snippet[:j]) + 1
+	}
+	return &ParseError{
+		Line:    i + 1,
+		Col:     col,
+		Snippet: snippet,
+		Msg:     fmt.Sprintf(format, args...),
+	}
+}
+
+// Helpers that parse a single line can't know where it is, so the caller places the error at line index i
+func atLine(err error, i int) error {
+	var parseErr *ParseError
+	if errors.As(err, &parseErr) {
+		parseErr.Line = i + 1
+	}
+	return err
+}
+
+// Splits a line on whitespace like strings.Fields, but also gives the byte offset of each field
+func fieldsAt(line string) (fields []string, offsets []int) {
+	start := -1
+	for j, rn := range line + " " {
+		if unicode.IsSpace(rn) {
+			if start >= 0 {
+				fields = append(fields, line[start:j])
+				offsets = append(offsets, start)
+				start = -1
+			}
+		} else if start < 0 {
+			start = j
+		}
+	}
+	return
+}
+
+// Splits a line like strings.Split, but also gives the byte offset of each part
+func splitAt(line string, sep string) (parts []string, offsets []int) {
+	parts = strings.Split(line, sep)
+	offsets = make([]int, len(parts))
+	off := 0
+	for k, part := range parts {
+		offsets[k] = off
+		off += len(part) + len(sep)
+	}
+	return
+}
+
+func (e *ParseError) Error() string {
+	msg := e.Msg
+	if msg == "" && e.Err != nil {
+		msg = e.Err.Error()
+	} else if e.Err != nil {
+		msg = fmt.Sprintf("%v: %v", msg, e.Err)
+	}
+
+	src := "input"
+	if e.File != "" {
+		src = e.File
+	}
+
+	if e.Line == 0 {
+		return fmt.Sprintf("Couldn't parse %v: %v", src, msg)
+	} else if e.Col == 0 {
+		return fmt.Sprintf("Couldn't parse %v at line %v: %v", src, e.Line, msg)
+	}
+	return fmt.Sprintf("Couldn't parse %v at line %v, column %v: %v", src, e.Line, e.Col, msg)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+const excerptWidth = 60
+
+// Shows the offending line with a caret under the bad column. Long lines are cut down to a window
+// around the column
+func (e *ParseError) Excerpt() string {
+	if e.Line == 0 || e.Snippet == "" {
+		return ""
+	}
+
+	runes := []rune(e.Snippet)
+	col := e.Col
+	start, end := 0, len(runes)
+	if len(runes) > excerptWidth {
+		start = max(0, min(col - 1 - excerptWidth / 2, len(runes) - excerptWidth))
+		end = start + excerptWidth
+	}
+
+	prefix, suffix := "", ""
+	if start > 0 {
+		prefix = "..."
+	}
+	if end < len(runes) {
+		suffix = "..."
+	}
+
+	gutter := fmt.Sprintf("%d", e.Line)
+	out := fmt.Sprintf("%v | %v%v%v", gutter, prefix, string(runes[start:end]), suffix)
+	if col == 0 {
+		return out
+	}
+
+	// Keep tabs so the caret lines up however the terminal renders them
+	var pad strings.Builder
+	pad.WriteString(strings.Repeat(" ", len(prefix)))
+	for _, rn := range runes[start:min(col - 1, end)] {
+		if rn == '\t' {
+			pad.WriteRune('\t')
+		} else {
+			pad.WriteRune(' ')
+		}
+	}
+	return fmt.Sprintf("%v\n%v | %v^", out, strings.Repeat(" ", len(gutter)), pad.String())
+}
+
+type NoSolutionError struct {
+	Msg string
+	Err error
+}
+
+func (e *NoSolutionError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("No solution: %v: %v", e.Msg, e.Err)
+	}
+	return fmt.Sprintf("No solution: %v", e.Msg)
+}
+
+func (e *NoSolutionError) Unwrap() error {
+	return e.Err
+}
+
+type TimeoutError struct {
+	Elapsed time.Duration
+	Err     error
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("Timed out after %v: %v", e.Elapsed.Round(time.Millisecond), e.Err)
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// Solvers hand back whatever error the cancelled loop gave them, so a blown deadline is recognized here
+func asTimeoutErr(err error, elapsed time.Duration) error {
+	var timeoutErr *TimeoutError
+	if errors.Is(err, context.DeadlineExceeded) && !errors.As(err, &timeoutErr) {
+		return &TimeoutError{Elapsed: elapsed, Err: err}
+	}
+	return err
+}
+
+type FlagError struct {
+	Flag string
+	Err  error
+}
+
+func (e *FlagError) Error() string {
+	if e.Flag == "" {
+		return fmt.Sprintf("Invalid flags: %v", e.Err)
+	}
+	return fmt.Sprintf("Invalid value for --%v: %v", e.Flag, e.Err)
+}
+
+func (e *FlagError) Unwrap() error {
+	return e.Err
+}
+
+func asParseErr(err error) error {
+	var parseErr *ParseError
+	if err == nil || errors.As(err, &parseErr) {
+		return err
+	}
+	return &ParseError{Err: err}
+}
+
+// Parsers trim the input before splitting it into lines, so the blank lines they dropped are added
+// back to point at the raw input. Most trim each line too, so when the snippet is the trimmed line the
+// column is moved past what was cut from its front and the raw line is shown instead. They also don't
+// know where the input came from, so that's filled in too
+func locateParseErr(err error, inputStr string, path string) error {
+	err = asParseErr(err)
+	var parseErr *ParseError
+	if errors.As(err, &parseErr) && parseErr.Line > 0 {
+		trimmed := strings.TrimLeftFunc(inputStr, unicode.IsSpace)
+		parseErr.Line += strings.Count(inputStr[:len(inputStr) - len(trimmed)], "\n")
+
+		lines := strings.Split(inputStr, "\n")
+		if parseErr.Line <= len(lines) {
+			raw := strings.TrimRight(lines[parseErr.Line - 1], "\r")
+			if parseErr.Snippet != "" && parseErr.Snippet == strings.TrimSpace(raw) {
+				lead := len(raw) - len(strings.TrimLeftFunc(raw, unicode.IsSpace))
+				if parseErr.Col > 0 {
+					parseErr.Col += utf8.RuneCountInString(raw[:lead])
+				}
+				parseErr.Snippet = raw
+			}
+		}
+	}
+	return withInputFile(err, path)
+}
+
+func withInputFile(err error, path string) error {
+	if path == stdinPath {
+		path = "stdin"
+	}
+	var parseErr *ParseError
+	if errors.As(err, &parseErr) && parseErr.File == "" {
+		parseErr.File = path
+	}
+	return err
+}
+
+func ExitCode(err error) int {
+	var inputErr *InputError
+	var parseErr *ParseError
+	var noSolErr *NoSolutionError
+	var flagErr *FlagError
+	var timeoutErr *TimeoutError
+
+	switch {
+	case err == nil:
+		return EXIT_OK
+	case errors.As(err, &flagErr):
+		return EXIT_FLAG
+	case errors.As(err, &timeoutErr):
+		return EXIT_TIMEOUT
+	case errors.As(err, &inputErr):
+		return EXIT_INPUT
+	case errors.As(err, &parseErr):
+		return EXIT_PARSE
+	case errors.As(err, &noSolErr):
+		return EXIT_NO_SOLUTION
+	default:
+		return EXIT_ERROR
+	}
+}
+
+func ErrorMessage(err error) string {
+	var flagErr *FlagError
+	var parseErr *ParseError
+	if errors.As(err, &flagErr) {
+		return fmt.Sprintf("%v\nRun 'aoc --help' for usage", err)
+	} else if errors.As(err, &parseErr) && parseErr.Excerpt() != "" {
+		return fmt.Sprintf("There was an error: %v\n%v", err, parseErr.Excerpt())
+	}
+	return fmt.Sprintf("There was an error: %v", err)
+}
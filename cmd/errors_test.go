@@ -0,0 +1,89 @@
+package cmd_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/dusktreader/advent-of-code-2024/cmd"
+	"github.com/dusktreader/advent-of-code-2024/util"
+)
+
+func TestExitCode(t *testing.T) {
+	base := fmt.Errorf("Boom")
+	cases := []struct{
+		err  error
+		want int
+	}{
+		{nil, cmd.EXIT_OK},
+		{base, cmd.EXIT_ERROR},
+		{&cmd.FlagError{Flag: "part", Err: base}, cmd.EXIT_FLAG},
+		{&cmd.InputError{Path: "nope.txt", Err: base}, cmd.EXIT_INPUT},
+		{&cmd.ParseError{Line: 3, Col: 2, Msg: "Bad rune"}, cmd.EXIT_PARSE},
+		{&cmd.NoSolutionError{Msg: "Stuck"}, cmd.EXIT_NO_SOLUTION},
+		{util.ReErr(&cmd.ParseError{Err: base}, "Wrapped"), cmd.EXIT_PARSE},
+		{fmt.Errorf("Wrapped: %w", &cmd.InputError{Err: base}), cmd.EXIT_INPUT},
+	}
+	for _, c := range cases {
+		got := cmd.ExitCode(c.err)
+		if got != c.want {
+			t.Errorf("Wrong exit code for %v: want %v, got %v", c.err, c.want, got)
+		}
+	}
+}
+
+func TestSolveErrors(t *testing.T) {
+	s, err := cmd.GetSolver(2)
+	util.Unexpect(t, err)
+
+	_, err = cmd.Solve(context.Background(), s, 1, "1 2 x")
+	if cmd.ExitCode(err) != cmd.EXIT_PARSE {
+		t.Errorf("Bad input should give a parse error, got %#v", err)
+	}
+
+	_, err = cmd.Solve(context.Background(), s, 3, "1 2 3")
+	if cmd.ExitCode(err) != cmd.EXIT_FLAG {
+		t.Errorf("Bad part should give a flag error, got %#v", err)
+	}
+}
+
+func TestParseErrorExcerpt(t *testing.T) {
+	err := &cmd.ParseError{File: "day15.txt", Line: 12, Col: 3, Snippet: "<^x>", Msg: "Unknown move 'x'"}
+	want := "Couldn't parse day15.txt at line 12, column 3: Unknown move 'x'"
+	if err.Error() != want {
+		t.Errorf("Wrong message: want %q, got %q", want, err.Error())
+	}
+
+	want = "12 | <^x>\n   |   ^"
+	if err.Excerpt() != want {
+		t.Errorf("Wrong excerpt: want %q, got %q", want, err.Excerpt())
+	}
+}
+
+func TestSolveParseErrorPosition(t *testing.T) {
+	s, err := cmd.GetSolver(2)
+	util.Unexpect(t, err)
+
+	_, err = cmd.Solve(context.Background(), s, 1, "\n\n1 2 3\n4 five 6\n")
+	var parseErr *cmd.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("Expected a ParseError, got %#v", err)
+	} else if parseErr.Line != 4 || parseErr.Col != 3 || parseErr.Snippet != "4 five 6" {
+		t.Errorf("Parse error should point at the raw input, got %+v", parseErr)
+	}
+
+	// The column counts the indent the parser trimmed off the line
+	s, err = cmd.GetSolver(6)
+	util.Unexpect(t, err)
+	_, err = cmd.Solve(context.Background(), s, 1, "..#\n  .x.\n...")
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("Expected a ParseError, got %#v", err)
+	} else if parseErr.Line != 2 || parseErr.Col != 4 || parseErr.Snippet != "  .x." {
+		t.Errorf("Parse error should point at the raw line, got %+v", parseErr)
+	}
+	want := "2 |   .x.\n  |    ^"
+	if parseErr.Excerpt() != want {
+		t.Errorf("Wrong excerpt: want %q, got %q", want, parseErr.Excerpt())
+	}
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// Unexported helpers that the external tests need to reach
+var (
+	Locate    = locate
+	LoadInput = loadInput
+)
+
+// Builds a fresh command tree for one day and returns its part 1 command with args parsed. The inputs
+// directory flag is its own so tests don't leak it to each other. The other root flags are shared
+func PartCmd(day int, args ...string) (*cobra.Command, error) {
+	s, err := GetSolver(day)
+	if err != nil {
+		return nil, err
+	}
+
+	root := &cobra.Command{Use: "aoc"}
+	root.PersistentFlags().String("inputs-dir", "", "")
+	root.PersistentFlags().AddFlagSet(rootCmd.PersistentFlags())
+	dayCmd := makeDayCmd(s)
+	root.AddCommand(dayCmd)
+
+	partCmd, _, err := root.Find([]string{DayName(day), PartName(1)})
+	if err != nil {
+		return nil, err
+	}
+	return partCmd, partCmd.ParseFlags(args)
+}
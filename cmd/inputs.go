@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.PersistentFlags().String(
+		"inputs-dir",
+		"",
+		fmt.Sprintf("Where to find dayNN.txt inputs. Defaults to $%v, then the nearest %v/ directory", INPUTS_ENV, defaultInputsDir),
+	)
+}
+
+const (
+	INPUTS_ENV       = "AOC_INPUTS_DIR"
+	defaultInputsDir = "inputs"
+	stdinPath        = "-"
+)
+
+// Day commands carry their day here so subcommands can find their default input
+const dayAnnotation = "day"
+
+func addInputFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringP("input-file", "i", "", "Read input from this file, or '-' for stdin")
+	cmd.PersistentFlags().Int("sample", 0, "Use the Nth sample input (dayNN_sample.txt, dayNN_sample2.txt, ...) instead")
+	cmd.PersistentFlags().Lookup("sample").NoOptDefVal = "1"
+}
+
+func InputName(day int, sample int) string {
+	switch {
+	case sample <= 0:
+		return DayName(day) + ".txt"
+	case sample == 1:
+		return DayName(day) + "_sample.txt"
+	default:
+		return fmt.Sprintf("%v_sample%v.txt", DayName(day), sample)
+	}
+}
+
+// An explicit directory is the only place searched. Otherwise inputs/ is looked for here and in every
+// parent so commands work from anywhere in the repo
+func inputsDirs(cmd *cobra.Command) ([]string, error) {
+	dir, err := cmd.Flags().GetString("inputs-dir")
+	if err != nil {
+		return nil, &FlagError{Flag: "inputs-dir", Err: err}
+	}
+	if dir == "" {
+		dir = os.Getenv(INPUTS_ENV)
+	}
+	if dir != "" {
+		return []string{dir}, nil
+	}
+
+	return searchUp(defaultInputsDir), nil
+}
+
+// Where name could be, starting in the working directory and going up through every parent
+func searchUp(name string) []string {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return []string{name}
+	}
+
+	paths := make([]string, 0)
+	for {
+		paths = append(paths, filepath.Join(cwd, name))
+		parent := filepath.Dir(cwd)
+		if parent == cwd {
+			return paths
+		}
+		cwd = parent
+	}
+}
+
+// The first path that exists, or the first one if none do
+func firstExisting(paths []string) string {
+	for _, path := range paths {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return paths[0]
+}
+
+func getInputsDir(cmd *cobra.Command) (string, error) {
+	dirs, err := inputsDirs(cmd)
+	if err != nil {
+		return "", err
+	}
+	return firstExisting(dirs), nil
+}
+
+func findInput(dirs []string, name string) (string, error) {
+	searched := make([]string, len(dirs))
+	for i, dir := range dirs {
+		searched[i] = filepath.Join(dir, name)
+		if _, err := os.Stat(searched[i]); err == nil {
+			return searched[i], nil
+		}
+	}
+	return "", &InputError{
+		Path: name,
+		Err:  fmt.Errorf("%w. Searched:\n  %v", fs.ErrNotExist, strings.Join(searched, "\n  ")),
+	}
+}
+
+func cmdDay(cmd *cobra.Command) (int, bool) {
+	for c := cmd; c != nil; c = c.Parent() {
+		if val, ok := c.Annotations[dayAnnotation]; ok {
+			day, err := strconv.Atoi(val)
+			return day, err == nil
+		}
+	}
+	return 0, false
+}
+
+func stdinPiped() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode() & os.ModeCharDevice == 0
+}
+
+// Picks the input for a day command. An explicit --input-file wins, then --sample, then whatever is piped
+// to stdin, then the day's input from the inputs directory. `--sample 2` is read as `--sample=2`
+func loadInput(cmd *cobra.Command, args []string) (inputStr string, path string, err error) {
+	path, err = cmd.Flags().GetString("input-file")
+	if err != nil {
+		return "", "", &FlagError{Flag: "input-file", Err: err}
+	} else if path != "" {
+		inputStr, err = readInput(path)
+		return inputStr, path, err
+	}
+
+	sample, err := cmd.Flags().GetInt("sample")
+	if err != nil {
+		return "", "", &FlagError{Flag: "sample", Err: err}
+	}
+	if cmd.Flags().Lookup("sample").Changed && len(args) == 1 {
+		if n, err := strconv.Atoi(args[0]); err == nil {
+			sample = n
+		}
+	}
+	if sample < 0 {
+		return "", "", &FlagError{Flag: "sample", Err: fmt.Errorf("There is no sample %v", sample)}
+	}
+
+	day, hasDay := cmdDay(cmd)
+	if sample == 0 && (stdinPiped() || !hasDay) {
+		inputStr, err = readInput(stdinPath)
+		if err == nil || !hasDay {
+			return inputStr, stdinPath, err
+		}
+		logger.Debug("Nothing on stdin, falling back to the default input", "err", err)
+	}
+
+	dirs, err := inputsDirs(cmd)
+	if err != nil {
+		return "", "", err
+	}
+	path, err = findInput(dirs, InputName(day, sample))
+	if err != nil {
+		return "", "", err
+	}
+	inputStr, err = readInput(path)
+	return inputStr, path, err
+}
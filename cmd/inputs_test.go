@@ -0,0 +1,178 @@
+package cmd_test
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dusktreader/advent-of-code-2024/cmd"
+	"github.com/dusktreader/advent-of-code-2024/util"
+)
+
+func TestInputName(t *testing.T) {
+	cases := []struct{
+		day    int
+		sample int
+		want   string
+	}{
+		{6, 0, "day06.txt"},
+		{6, 1, "day06_sample.txt"},
+		{16, 2, "day16_sample2.txt"},
+	}
+	for _, c := range cases {
+		got := cmd.InputName(c.day, c.sample)
+		if got != c.want {
+			t.Errorf("Wrong input name for day %v, sample %v: want %v, got %v", c.day, c.sample, c.want, got)
+		}
+	}
+}
+
+// Writes each file into dir, making any directories needed
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		err := os.MkdirAll(filepath.Dir(path), 0755)
+		util.Unexpect(t, err)
+		err = os.WriteFile(path, []byte(content), 0644)
+		util.Unexpect(t, err)
+	}
+}
+
+// Stands in for stdin for the rest of the test. Nil content looks like a terminal, so nothing is piped
+func fakeStdin(t *testing.T, content *string) {
+	var f *os.File
+	var err error
+	if content == nil {
+		f, err = os.Open(os.DevNull)
+	} else {
+		path := filepath.Join(t.TempDir(), "stdin")
+		err = os.WriteFile(path, []byte(*content), 0644)
+		util.Unexpect(t, err)
+		f, err = os.Open(path)
+	}
+	util.Unexpect(t, err)
+
+	old := os.Stdin
+	os.Stdin = f
+	t.Cleanup(func() {
+		os.Stdin = old
+		f.Close()
+	})
+}
+
+func TestLoadInput(t *testing.T) {
+	dir := t.TempDir()
+	other := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"day06.txt":         "default",
+		"day06_sample.txt":  "sample",
+		"day06_sample2.txt": "sample2",
+		"explicit.txt":      "explicit",
+	})
+	writeFiles(t, other, map[string]string{"day06.txt": "other"})
+	t.Setenv(cmd.INPUTS_ENV, dir)
+	piped := "piped"
+	explicit := filepath.Join(dir, "explicit.txt")
+
+	cases := []struct{
+		name  string
+		args  []string
+		stdin *string
+		want  string
+	}{
+		{"default", nil, nil, "default"},
+		{"sample", []string{"--sample"}, nil, "sample"},
+		{"numbered sample", []string{"--sample=2"}, nil, "sample2"},
+		{"stdin", nil, &piped, "piped"},
+		{"sample over stdin", []string{"--sample"}, &piped, "sample"},
+		{"file over sample", []string{"--input-file", explicit, "--sample"}, &piped, "explicit"},
+		{"file from stdin", []string{"--input-file", "-"}, &piped, "piped"},
+		{"flag over env", []string{"--inputs-dir", other}, nil, "other"},
+	}
+	for _, c := range cases {
+		fakeStdin(t, c.stdin)
+		partCmd, err := cmd.PartCmd(6, c.args...)
+		util.Unexpect(t, err)
+
+		got, _, err := cmd.LoadInput(partCmd, partCmd.Flags().Args())
+		if err != nil {
+			t.Errorf("Unexpected error for %v: %v", c.name, err)
+		} else if got != c.want {
+			t.Errorf("Wrong input for %v: want %q, got %q", c.name, c.want, got)
+		}
+	}
+}
+
+func TestLoadInputSearch(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{"inputs/day06.txt": "found"})
+	nested := filepath.Join(root, "cmd", "deeper")
+	err := os.MkdirAll(nested, 0755)
+	util.Unexpect(t, err)
+	chdir(t, nested)
+	t.Setenv(cmd.INPUTS_ENV, "")
+	fakeStdin(t, nil)
+
+	partCmd, err := cmd.PartCmd(6)
+	util.Unexpect(t, err)
+	got, path, err := cmd.LoadInput(partCmd, nil)
+	util.Unexpect(t, err)
+	if got != "found" || path != filepath.Join(root, "inputs", "day06.txt") {
+		t.Errorf("Input should be found in a parent directory, got %q from %v", got, path)
+	}
+
+	// Every place that was tried is listed when nothing turns up
+	partCmd, err = cmd.PartCmd(6, "--sample")
+	util.Unexpect(t, err)
+	_, _, err = cmd.LoadInput(partCmd, nil)
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("Expected a missing input error, got %v", err)
+	}
+	for _, dir := range []string{nested, root} {
+		want := filepath.Join(dir, "inputs", "day06_sample.txt")
+		if !strings.Contains(err.Error(), "Searched:") || !strings.Contains(err.Error(), want) {
+			t.Errorf("Error should list %v as searched: %v", want, err)
+		}
+	}
+}
+
+func TestStdinParseError(t *testing.T) {
+	bad := "1 2\nnope\n"
+	fakeStdin(t, &bad)
+	t.Setenv(cmd.INPUTS_ENV, t.TempDir())
+
+	partCmd, err := cmd.PartCmd(2)
+	util.Unexpect(t, err)
+	partCmd.SetContext(context.Background())
+	err = partCmd.RunE(partCmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "Couldn't parse stdin at line 2") {
+		t.Errorf("Parse error should name stdin, got %v", err)
+	}
+}
+
+func TestFileParseError(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"bad02.txt": "1 2\nnope\n"})
+	path := filepath.Join(dir, "bad02.txt")
+	fakeStdin(t, nil)
+
+	partCmd, err := cmd.PartCmd(2, "--input-file", path)
+	util.Unexpect(t, err)
+	partCmd.SetContext(context.Background())
+	err = partCmd.RunE(partCmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "Couldn't parse " + path + " at line 2") {
+		t.Errorf("Parse error should name the input file, got %v", err)
+	}
+
+	s, err := cmd.GetSolver(2)
+	util.Unexpect(t, err)
+	results := cmd.RunJobs(context.Background(), []cmd.RunJob{{Solver: s, Part: 1, InputFile: path}}, 1, 0)
+	var parseErr *cmd.ParseError
+	if !errors.As(results[0].Err, &parseErr) || parseErr.File != path {
+		t.Errorf("Parse error from a job should name its input file, got %v", results[0].Err)
+	}
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/dusktreader/advent-of-code-2024/util"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.PersistentFlags().String("log-format", util.LOG_TEXT, "How to write logs: text or json")
+	rootCmd.PersistentFlags().String("log-file", "", "Write logs to this file instead of stderr")
+	rootCmd.PersistentFlags().String(
+		"log-level",
+		"",
+		"Log levels like 'debug' or 'graph=debug,heap=warn'. Loggers are cmd, graph, heap and util",
+	)
+}
+
+var logger = util.Logger("cmd")
+
+var logFile *os.File
+
+func setupLogging(cmd *cobra.Command) error {
+	verbose, err := cmd.Flags().GetBool("verbose")
+	if err != nil {
+		return &FlagError{Flag: "verbose", Err: err}
+	}
+
+	format, err := cmd.Flags().GetString("log-format")
+	if err != nil {
+		return &FlagError{Flag: "log-format", Err: err}
+	}
+
+	path, err := cmd.Flags().GetString("log-file")
+	if err != nil {
+		return &FlagError{Flag: "log-file", Err: err}
+	}
+
+	spec, err := cmd.Flags().GetString("log-level")
+	if err != nil {
+		return &FlagError{Flag: "log-level", Err: err}
+	}
+
+	level := slog.LevelInfo
+	if verbose {
+		level = slog.LevelDebug
+	}
+	fallback, levels, err := util.ParseLogLevels(spec)
+	if err != nil {
+		return &FlagError{Flag: "log-level", Err: err}
+	} else if fallback != nil {
+		level = *fallback
+	}
+
+	var w io.Writer = os.Stderr
+	if path != "" {
+		logFile, err = os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return &FlagError{Flag: "log-file", Err: err}
+		}
+		w = logFile
+	}
+
+	err = util.ConfigureLogs(w, format, level, levels)
+	if err != nil {
+		return &FlagError{Flag: "log-format", Err: err}
+	}
+	return nil
+}
+
+func closeLogs() {
+	if logFile == nil {
+		return
+	}
+	err := logFile.Close()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Couldn't close log file: %v\n", err)
+	}
+	logFile = nil
+}
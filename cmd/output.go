@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"sync"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.PersistentFlags().String("format", FORMAT_TEXT, "How to print results: text or json")
+}
+
+const (
+	FORMAT_TEXT = "text"
+	FORMAT_JSON = "json"
+)
+
+func getFormat(cmd *cobra.Command) (string, error) {
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return "", &FlagError{Flag: "format", Err: err}
+	}
+	switch format {
+	case FORMAT_TEXT, FORMAT_JSON:
+		return format, nil
+	default:
+		return "", &FlagError{Flag: "format", Err: fmt.Errorf("Unknown format %q, expected text or json", format)}
+	}
+}
+
+// Solver specific details that come along with an answer, like the breakdown behind a count
+type Extras struct {
+	mu   sync.Mutex
+	vals map[string]any
+}
+
+type extrasKey struct{}
+
+func withExtras(ctx context.Context) (context.Context, *Extras) {
+	extras := &Extras{vals: make(map[string]any)}
+	return context.WithValue(ctx, extrasKey{}, extras), extras
+}
+
+// Records an extra detail for the current run. Does nothing if nobody is collecting them
+func AddExtra(ctx context.Context, key string, val any) {
+	extras, ok := ctx.Value(extrasKey{}).(*Extras)
+	if !ok {
+		return
+	}
+	extras.mu.Lock()
+	defer extras.mu.Unlock()
+	extras.vals[key] = val
+}
+
+func (e *Extras) Map() map[string]any {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if len(e.vals) == 0 {
+		return nil
+	}
+	return maps.Clone(e.vals)
+}
+
+func hashInput(inputStr string) string {
+	sum := sha256.Sum256([]byte(inputStr))
+	return hex.EncodeToString(sum[:])
+}
+
+type ResultJSON struct {
+	Day       int            `json:"day"`
+	Part      int            `json:"part"`
+	Answer    any            `json:"answer"`
+	Input     string         `json:"input"`
+	InputHash string         `json:"input_sha256,omitempty"`
+	ParseNs   int64          `json:"parse_ns"`
+	SolveNs   int64          `json:"solve_ns"`
+	Allocs    *uint64        `json:"allocs,omitempty"`
+	Bytes     *uint64        `json:"bytes,omitempty"`
+	Extras    map[string]any `json:"extras,omitempty"`
+	Skipped   bool           `json:"skipped,omitempty"`
+	Error     string         `json:"error,omitempty"`
+}
+
+func (r RunResult) JSON() ResultJSON {
+	rj := ResultJSON{
+		Day:       r.Day,
+		Part:      r.Part,
+		Answer:    r.Answer,
+		Input:     r.InputFile,
+		InputHash: r.InputHash,
+		ParseNs:   r.Timing.Parse.Nanoseconds(),
+		SolveNs:   r.Timing.Solve.Nanoseconds(),
+		Extras:    r.Extras,
+		Skipped:   r.Skipped,
+	}
+	if r.Measured {
+		rj.Allocs = &r.Allocs
+		rj.Bytes = &r.Bytes
+	}
+	if r.Err != nil {
+		rj.Error = r.Err.Error()
+	}
+	return rj
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	err := enc.Encode(v)
+	if err != nil {
+		return fmt.Errorf("Couldn't write results as JSON: %w", err)
+	}
+	return nil
+}
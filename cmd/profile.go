@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.PersistentFlags().String("cpuprofile", "", "Write a CPU profile to this file")
+	rootCmd.PersistentFlags().String("memprofile", "", "Write a heap profile to this file when the command finishes")
+	rootCmd.PersistentFlags().String("trace", "", "Write an execution trace to this file")
+	rootCmd.PersistentFlags().String("blockprofile", "", "Write a blocking profile to this file when the command finishes")
+	rootCmd.PersistentFlags().Bool("time", false, "Print how long parsing and solving took to stderr")
+}
+
+type profiler struct {
+	cpuFile   *os.File
+	traceFile *os.File
+	memPath   string
+	blockPath string
+}
+
+// Only one command runs per process, so the profiles in flight live here until stopProfiling
+var prof *profiler
+
+func startProfiling(cmd *cobra.Command) (err error) {
+	p := &profiler{}
+	prof = p
+	defer func() {
+		if err != nil {
+			_ = stopProfiling()
+		}
+	}()
+
+	paths := make(map[string]string)
+	for _, name := range []string{"cpuprofile", "memprofile", "trace", "blockprofile"} {
+		paths[name], err = cmd.Flags().GetString(name)
+		if err != nil {
+			return &FlagError{Flag: name, Err: err}
+		}
+	}
+
+	if path := paths["cpuprofile"]; path != "" {
+		p.cpuFile, err = os.Create(path)
+		if err != nil {
+			return &FlagError{Flag: "cpuprofile", Err: err}
+		}
+		err = pprof.StartCPUProfile(p.cpuFile)
+		if err != nil {
+			return fmt.Errorf("Couldn't start CPU profile: %w", err)
+		}
+		logger.Debug("Started CPU profile", "file", path)
+	}
+
+	if path := paths["trace"]; path != "" {
+		p.traceFile, err = os.Create(path)
+		if err != nil {
+			return &FlagError{Flag: "trace", Err: err}
+		}
+		err = trace.Start(p.traceFile)
+		if err != nil {
+			return fmt.Errorf("Couldn't start trace: %w", err)
+		}
+		logger.Debug("Started trace", "file", path)
+	}
+
+	p.memPath = paths["memprofile"]
+	p.blockPath = paths["blockprofile"]
+	if p.blockPath != "" {
+		runtime.SetBlockProfileRate(1)
+	}
+	return nil
+}
+
+func writeProfile(name string, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("Couldn't write %v profile: %w", name, err)
+	}
+	defer f.Close()
+
+	if name == "heap" {
+		runtime.GC()
+	}
+	err = pprof.Lookup(name).WriteTo(f, 0)
+	if err != nil {
+		return fmt.Errorf("Couldn't write %v profile: %w", name, err)
+	}
+	logger.Debug("Wrote profile", "profile", name, "file", path)
+	return nil
+}
+
+// Safe to call more than once. Execute calls it again so profiles are flushed even when the command failed
+func stopProfiling() error {
+	p := prof
+	if p == nil {
+		return nil
+	}
+	prof = nil
+
+	var errs []error
+	if p.cpuFile != nil {
+		pprof.StopCPUProfile()
+		errs = append(errs, p.cpuFile.Close())
+	}
+	if p.traceFile != nil {
+		trace.Stop()
+		errs = append(errs, p.traceFile.Close())
+	}
+	if p.memPath != "" {
+		errs = append(errs, writeProfile("heap", p.memPath))
+	}
+	if p.blockPath != "" {
+		errs = append(errs, writeProfile("block", p.blockPath))
+		runtime.SetBlockProfileRate(0)
+	}
+	return errors.Join(errs...)
+}
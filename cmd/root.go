@@ -1,89 +1,101 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"log"
-	"log/slog"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 )
 
 func init() {
-	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Show verbose logging output")
+	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Show debug logs from every package")
+	rootCmd.PersistentFlags().Duration("timeout", 0, "Give up on a solution after this long (0 means no limit)")
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return &FlagError{Err: err}
+	})
 }
 
 var rootCmd = &cobra.Command{
 	Use:   "aoc",
 	Short: "Advent of Code - 2024",
 	Long:  "The Advent of Code submission for dusktreader@github.com in 2024",
-	PersistentPreRun: preRun,
+	PersistentPreRunE: preRun,
+	PersistentPostRunE: postRun,
 	Run:   rootMain,
+	SilenceErrors: true,
+	SilenceUsage:  true,
 }
 
-func preRun(cmd *cobra.Command, args []string) {
+func preRun(cmd *cobra.Command, args []string) error {
 	log.SetFlags(log.LstdFlags | log.Lshortfile)
 
-	verbose, err := cmd.Flags().GetBool("verbose")
-	MaybeDie(err)
-	if verbose {
-		slog.SetLogLoggerLevel(slog.LevelDebug)
-	} else {
-		slog.SetLogLoggerLevel(slog.LevelInfo)
+	err := setupLogging(cmd)
+	if err != nil {
+		return err
 	}
+	return startProfiling(cmd)
+}
+
+func postRun(cmd *cobra.Command, args []string) error {
+	return stopProfiling()
 }
 
 func rootMain(cmd *cobra.Command, args []string) {
 	_ = cmd.Help()
 }
 
-func getViz(cmd *cobra.Command, args []string) int {
-	viz, err := cmd.Flags().GetCount("visualize")
-	MaybeDie(err)
-	return viz
+func getTimeout(cmd *cobra.Command) (time.Duration, error) {
+	timeout, err := cmd.Flags().GetDuration("timeout")
+	if err != nil {
+		return 0, &FlagError{Flag: "timeout", Err: err}
+	} else if timeout < 0 {
+		return 0, &FlagError{Flag: "timeout", Err: fmt.Errorf("Timeout can't be negative: %v", timeout)}
+	}
+	return timeout, nil
 }
 
-func loadInput(cmd *cobra.Command, args []string) (inputStr string, err error) {
-	inputFile, err := cmd.Flags().GetString("input-file")
-	if err != nil {
-		return "", fmt.Errorf("Couldn't get input-file argument: %#v", err)
+// A zero timeout means no limit
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout == 0 {
+		return context.WithCancel(ctx)
 	}
+	return context.WithTimeout(ctx, timeout)
+}
 
+func readInput(inputFile string) (inputStr string, err error) {
 	var input []byte
 
-	if inputFile != "" {
-		slog.Debug("Input file provided. Reading from file", "file", inputFile)
+	if inputFile != "" && inputFile != stdinPath {
+		logger.Debug("Reading input from file", "file", inputFile)
 		input, err = os.ReadFile(inputFile)
 	} else {
-		slog.Debug("No input file provided. Reading from stdin")
+		logger.Debug("Reading input from stdin")
 		input, err = io.ReadAll(os.Stdin)
 	}
 	if err != nil {
-		return "", fmt.Errorf("Couldn't read input: %#v", err)
+		return "", &InputError{Path: inputFile, Err: err}
 	}
 
 	inputStr = string(input)
 	if inputStr == "" {
-		return "", fmt.Errorf("Didn't get any input")
+		return "", &InputError{Path: inputFile, Err: fmt.Errorf("Didn't get any input")}
 	}
 	return inputStr, nil
 }
 
-func MaybeDie(err error) {
+func Execute() {
+	addSolverCmds()
+	err := rootCmd.ExecuteContext(context.Background())
+	if profErr := stopProfiling(); profErr != nil {
+		fmt.Fprintln(os.Stderr, ErrorMessage(profErr))
+	}
+	closeLogs()
 	if err != nil {
-		fmt.Fprintln(os.Stderr, "There was an error:", err)
-		os.Exit(1)
+		fmt.Fprintln(os.Stderr, ErrorMessage(err))
+		os.Exit(ExitCode(err))
 	}
 }
-
-func Die(msg string, flags ...interface{}) {
-	msg = fmt.Sprintf(msg, flags...)
-	fmt.Fprintln(os.Stderr, "Aborting:", msg)
-	os.Exit(1)
-}
-
-func Execute() {
-	err := rootCmd.Execute()
-	MaybeDie(err)
-}
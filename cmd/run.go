@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(runCmd)
+	runCmd.Flags().IntP("day", "d", 0, "The day to run")
+	runCmd.Flags().IntP("part", "p", 1, "The part of the day to run")
+	runCmd.Flags().StringP("input-file", "i", "", "Read input from this file, or '-' for stdin")
+	runCmd.Flags().Int("sample", 0, "Use the Nth sample input instead")
+	runCmd.Flags().Lookup("sample").NoOptDefVal = "1"
+}
+
+var runCmd = &cobra.Command{
+	Use:   "run --day N --part P [-- solver flags]",
+	Short: "Run one solution",
+	Long:  "Run the solution for one part of one day. Flags after -- are passed to the solver",
+	RunE:  runMain,
+}
+
+func runMain(cmd *cobra.Command, args []string) error {
+	day, err := cmd.Flags().GetInt("day")
+	if err != nil {
+		return &FlagError{Flag: "day", Err: err}
+	}
+
+	part, err := cmd.Flags().GetInt("part")
+	if err != nil {
+		return &FlagError{Flag: "part", Err: err}
+	}
+
+	inputFile, err := cmd.Flags().GetString("input-file")
+	if err != nil {
+		return &FlagError{Flag: "input-file", Err: err}
+	}
+
+	sample, err := cmd.Flags().GetInt("sample")
+	if err != nil {
+		return &FlagError{Flag: "sample", Err: err}
+	}
+
+	_, err = GetSolver(day)
+	if err != nil {
+		return &FlagError{Flag: "day", Err: err}
+	}
+
+	if part != 1 && part != 2 {
+		return &FlagError{Flag: "part", Err: fmt.Errorf("There is no part %v", part)}
+	}
+
+	partCmd, _, err := rootCmd.Find([]string{DayName(day), PartName(part)})
+	if err != nil || partCmd.RunE == nil {
+		return fmt.Errorf("Couldn't find a command for day %v, part %v", day, part)
+	}
+
+	if inputFile != "" {
+		args = append(args, "--input-file", inputFile)
+	}
+	if cmd.Flags().Lookup("sample").Changed {
+		args = append(args, fmt.Sprintf("--sample=%v", sample))
+	}
+	err = partCmd.ParseFlags(args)
+	if err != nil {
+		return &FlagError{Err: err}
+	}
+
+	partCmd.SetContext(cmd.Context())
+	return partCmd.RunE(partCmd, partCmd.Flags().Args())
+}
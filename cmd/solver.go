@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+type Solver interface {
+	Day() int
+	Parse(inputStr string) (any, error)
+	Part1(ctx context.Context, input any) (any, error)
+	Part2(ctx context.Context, input any) (any, error)
+}
+
+// Solvers that need extra options add them here. Part 0 is the day command itself
+type FlagSolver interface {
+	Solver
+	Flags(part int, cmd *cobra.Command)
+}
+
+// Solvers that have extra tools (visualizers, alternate runs) attach them to the day command
+type CmdSolver interface {
+	Solver
+	Commands() []*cobra.Command
+}
+
+var solvers = make(map[int]Solver)
+
+func Register(s Solver) {
+	if _, ok := solvers[s.Day()]; ok {
+		panic(fmt.Sprintf("Solver for day %v was registered twice", s.Day()))
+	}
+	solvers[s.Day()] = s
+}
+
+func GetSolver(day int) (Solver, error) {
+	s, ok := solvers[day]
+	if !ok {
+		return nil, fmt.Errorf("No solver registered for day %v", day)
+	}
+	return s, nil
+}
+
+func Solvers() []Solver {
+	days := make([]int, 0, len(solvers))
+	for day := range solvers {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+
+	ss := make([]Solver, len(days))
+	for i, day := range days {
+		ss[i] = solvers[day]
+	}
+	return ss
+}
+
+func DayName(day int) string {
+	return fmt.Sprintf("day%02d", day)
+}
+
+func PartName(part int) string {
+	return fmt.Sprintf("part%d", part)
+}
+
+func SolvePart(ctx context.Context, s Solver, part int, input any) (any, error) {
+	switch part {
+	case 1:
+		return s.Part1(ctx, input)
+	case 2:
+		return s.Part2(ctx, input)
+	default:
+		return nil, &FlagError{Flag: "part", Err: fmt.Errorf("Day %v has no part %v", s.Day(), part)}
+	}
+}
+
+type Timing struct {
+	Parse time.Duration
+	Solve time.Duration
+}
+
+func Solve(ctx context.Context, s Solver, part int, inputStr string) (any, error) {
+	answer, _, err := SolveTimed(ctx, s, part, inputStr, "")
+	return answer, err
+}
+
+// InputFile is only used to name the input in parse errors and may be left empty
+func SolveTimed(
+	ctx context.Context,
+	s Solver,
+	part int,
+	inputStr string,
+	inputFile string,
+) (answer any, timing Timing, err error) {
+	start := time.Now()
+	input, err := s.Parse(inputStr)
+	timing.Parse = time.Since(start)
+	if err != nil {
+		return nil, timing, locateParseErr(err, inputStr, inputFile)
+	}
+
+	start = time.Now()
+	answer, err = SolvePart(ctx, s, part, input)
+	timing.Solve = time.Since(start)
+	return answer, timing, asTimeoutErr(err, timing.Parse + timing.Solve)
+}
+
+func makeDayCmd(s Solver) *cobra.Command {
+	dayCmd := &cobra.Command{
+		Use:   DayName(s.Day()),
+		Short: fmt.Sprintf("Day %v Solutions", s.Day()),
+		Long:  fmt.Sprintf("The solutions for day %v of Advent of Code 2024", s.Day()),
+		Run:   func(cmd *cobra.Command, args []string) { _ = cmd.Help() },
+		Annotations: map[string]string{dayAnnotation: fmt.Sprint(s.Day())},
+	}
+	addInputFlags(dayCmd)
+
+	fs, hasFlags := s.(FlagSolver)
+	if hasFlags {
+		fs.Flags(0, dayCmd)
+	}
+
+	for _, part := range []int{1, 2} {
+		partCmd := makePartCmd(s, part)
+		if hasFlags {
+			fs.Flags(part, partCmd)
+		}
+		dayCmd.AddCommand(partCmd)
+	}
+
+	if cs, ok := s.(CmdSolver); ok {
+		dayCmd.AddCommand(cs.Commands()...)
+	}
+	return dayCmd
+}
+
+func makePartCmd(s Solver, part int) *cobra.Command {
+	return &cobra.Command{
+		Use:   PartName(part),
+		Short: fmt.Sprintf("Day %v, %v Solution", s.Day(), part),
+		Long:  fmt.Sprintf("The solution for day %v, part %v of Advent of Code 2024", s.Day(), part),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := getFormat(cmd)
+			if err != nil {
+				return err
+			}
+
+			timeout, err := getTimeout(cmd)
+			if err != nil {
+				return err
+			}
+
+			inputStr, inputFile, err := loadInput(cmd, args)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := withTimeout(cmd.Context(), timeout)
+			defer cancel()
+
+			res := RunSolver(ctx, s, part, inputStr, inputFile)
+
+			if showTime, _ := cmd.Flags().GetBool("time"); showTime {
+				fmt.Fprintf(os.Stderr, "parse: %v\nsolve: %v\n", res.Timing.Parse, res.Timing.Solve)
+			}
+
+			if format == FORMAT_JSON {
+				err = writeJSON(os.Stdout, res.JSON())
+				if err != nil {
+					return err
+				}
+			} else if res.Err == nil {
+				fmt.Printf("%v\n", res.Answer)
+			}
+			return res.Err
+		},
+	}
+}
+
+var solverCmdsAdded = false
+
+func addSolverCmds() {
+	if solverCmdsAdded {
+		return
+	}
+	for _, s := range Solvers() {
+		rootCmd.AddCommand(makeDayCmd(s))
+	}
+	solverCmdsAdded = true
+}
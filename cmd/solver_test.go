@@ -0,0 +1,62 @@
+package cmd_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dusktreader/advent-of-code-2024/cmd"
+	"github.com/dusktreader/advent-of-code-2024/util"
+)
+
+func TestSolversSorted(t *testing.T) {
+	solvers := cmd.Solvers()
+	if len(solvers) == 0 {
+		t.Fatalf("No solvers were registered")
+	}
+
+	for i := 1; i < len(solvers); i++ {
+		if solvers[i - 1].Day() >= solvers[i].Day() {
+			t.Errorf("Solvers out of order: day %v before day %v", solvers[i - 1].Day(), solvers[i].Day())
+		}
+	}
+}
+
+func TestGetSolver(t *testing.T) {
+	s, err := cmd.GetSolver(1)
+	util.Unexpect(t, err)
+	if s.Day() != 1 {
+		t.Errorf("Got the wrong solver: want day 1, got day %v", s.Day())
+	}
+
+	_, err = cmd.GetSolver(99)
+	if err == nil {
+		t.Errorf("Did not get error for unregistered day!")
+	}
+}
+
+func TestSolve(t *testing.T) {
+	s, err := cmd.GetSolver(1)
+	util.Unexpect(t, err)
+
+	inputStr := "3   4\n4   3\n2   5\n1   3\n3   9\n3   3\n"
+
+	cases := []struct{
+		part int
+		want int
+	}{
+		{1, 11},
+		{2, 31},
+	}
+	for _, c := range cases {
+		got, err := cmd.Solve(context.Background(), s, c.part, inputStr)
+		util.Unexpect(t, err)
+		if got != c.want {
+			t.Errorf("Wrong answer for part %v: want %v, got %v", c.part, c.want, got)
+		}
+	}
+
+	_, err = cmd.Solve(context.Background(), s, 3, inputStr)
+	if err == nil {
+		t.Errorf("Did not get error for invalid part!")
+	}
+}
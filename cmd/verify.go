@@ -0,0 +1,355 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(verifyCmd)
+	verifyCmd.Flags().BoolP("record", "r", false, "Record the computed answers instead of checking them")
+	verifyCmd.Flags().StringP(
+		"answers-file",
+		"a",
+		"",
+		fmt.Sprintf("The file holding the known answers. Defaults to the nearest %v", defaultAnswersFile),
+	)
+	verifyCmd.Flags().StringP(
+		"outputs-dir",
+		"o",
+		"",
+		fmt.Sprintf("A directory of dayNN_partP.txt answer fixtures. Defaults to the nearest %v/", defaultOutputsDir),
+	)
+	verifyCmd.Flags().IntP("jobs", "j", runtime.NumCPU(), "How many solutions to run at once")
+}
+
+const (
+	defaultAnswersFile = "answers.json"
+	defaultOutputsDir  = "outputs"
+)
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Check solutions against known answers",
+	Long:  "Run every solution against every input (including samples) and compare with the recorded answers",
+	RunE:  verifyMain,
+}
+
+type AnswerKey struct {
+	Day   int    `json:"day"`
+	Part  int    `json:"part"`
+	Input string `json:"input"`
+}
+
+func (k AnswerKey) less(o AnswerKey) bool {
+	if k.Day != o.Day {
+		return k.Day < o.Day
+	} else if k.Input != o.Input {
+		return k.Input < o.Input
+	}
+	return k.Part < o.Part
+}
+
+type Answer struct {
+	AnswerKey
+	Answer string `json:"answer"`
+}
+
+type Answers map[AnswerKey]string
+
+// A missing file is an error, since checking against no answers at all would always pass
+func LoadAnswers(path string) (Answers, error) {
+	answers := make(Answers)
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, &InputError{Path: path, Err: fmt.Errorf("No answers file, use --record to start one: %w", err)}
+	} else if err != nil {
+		return nil, fmt.Errorf("Couldn't read answers file %v: %w", path, err)
+	}
+
+	var entries []Answer
+	err = json.Unmarshal(data, &entries)
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't parse answers file %v: %w", path, err)
+	}
+
+	for _, e := range entries {
+		answers[e.AnswerKey] = e.Answer
+	}
+	return answers, nil
+}
+
+func (a Answers) Save(path string) error {
+	entries := make([]Answer, 0, len(a))
+	for k, v := range a {
+		entries = append(entries, Answer{AnswerKey: k, Answer: v})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].less(entries[j].AnswerKey) })
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("Couldn't encode answers: %w", err)
+	}
+
+	err = os.WriteFile(path, append(data, '\n'), 0644)
+	if err != nil {
+		return fmt.Errorf("Couldn't write answers file %v: %w", path, err)
+	}
+	return nil
+}
+
+// Fixtures are named after the input they belong to: day06_sample.txt part 2 -> day06_sample_part2.txt.
+// Nothing else in the directory is read. In particular outputs/day06.txt is the map that day 6 draws with
+// --output-file, not an answer, so it isn't a fixture
+func LoadFixture(dir string, input string, part int) (string, bool) {
+	name := fmt.Sprintf("%v_%v.txt", strings.TrimSuffix(input, filepath.Ext(input)), PartName(part))
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return "", false
+	}
+	return strings.TrimSpace(string(data)), true
+}
+
+// An explicit path from the flag is used as is. Otherwise name is looked for like the inputs directory is
+func locate(cmd *cobra.Command, flag string, name string) (string, error) {
+	path, err := cmd.Flags().GetString(flag)
+	if err != nil {
+		return "", &FlagError{Flag: flag, Err: err}
+	} else if path != "" {
+		return path, nil
+	}
+	return firstExisting(searchUp(name)), nil
+}
+
+func FindInputs(dir string, day int) []string {
+	inputs := make([]string, 0)
+	for _, pattern := range []string{DayName(day) + ".txt", DayName(day) + "_*.txt"} {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			continue
+		}
+		sort.Strings(matches)
+		inputs = append(inputs, matches...)
+	}
+	return inputs
+}
+
+// Every part of every day against each of its inputs. Finding no inputs at all is an error, since there
+// would be nothing to check
+func VerifyJobs(inputsDir string) ([]RunJob, error) {
+	jobs := make([]RunJob, 0)
+	for _, s := range Solvers() {
+		for _, inputFile := range FindInputs(inputsDir, s.Day()) {
+			for _, part := range []int{1, 2} {
+				jobs = append(jobs, RunJob{Solver: s, Part: part, InputFile: inputFile})
+			}
+		}
+	}
+	if len(jobs) == 0 {
+		return nil, &InputError{Path: inputsDir, Err: fmt.Errorf("No inputs found to verify")}
+	}
+	return jobs, nil
+}
+
+const (
+	VERIFY_OK       = "ok"
+	VERIFY_MISMATCH = "MISMATCH"
+	VERIFY_NEW      = "new"
+	VERIFY_RECORDED = "recorded"
+	VERIFY_ERROR    = "ERROR"
+	VERIFY_MISSING  = "MISSING"
+)
+
+type Verdict struct {
+	RunResult
+	Expected string
+	Got      string
+	Status   string
+}
+
+func Verify(res RunResult, answers Answers, outputsDir string) Verdict {
+	v := Verdict{RunResult: res}
+	input := filepath.Base(res.InputFile)
+
+	expected, ok := answers[AnswerKey{Day: res.Day, Part: res.Part, Input: input}]
+	if !ok {
+		expected, ok = LoadFixture(outputsDir, input, res.Part)
+	}
+	if ok {
+		v.Expected = expected
+	}
+
+	if res.Err != nil {
+		v.Status = VERIFY_ERROR
+		return v
+	}
+
+	v.Got = fmt.Sprintf("%v", res.Answer)
+	if !ok {
+		v.Status = VERIFY_NEW
+	} else if strings.TrimSpace(v.Got) == strings.TrimSpace(expected) {
+		v.Status = VERIFY_OK
+	} else {
+		v.Status = VERIFY_MISMATCH
+	}
+	return v
+}
+
+// Known answers that no job ran against, because their input is gone or wasn't found. Each one is a failed
+// check, so a deleted input can't make verify pass
+func Unchecked(answers Answers, jobs []RunJob) []Verdict {
+	ran := make(map[AnswerKey]bool, len(jobs))
+	for _, j := range jobs {
+		ran[AnswerKey{Day: j.Solver.Day(), Part: j.Part, Input: filepath.Base(j.InputFile)}] = true
+	}
+
+	keys := make([]AnswerKey, 0)
+	for k := range answers {
+		if !ran[k] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].less(keys[j]) })
+
+	verdicts := make([]Verdict, len(keys))
+	for i, k := range keys {
+		res := RunResult{Day: k.Day, Part: k.Part, InputFile: k.Input, Err: &InputError{Path: k.Input, Err: fs.ErrNotExist}}
+		verdicts[i] = Verdict{RunResult: res, Expected: answers[k], Status: VERIFY_MISSING}
+	}
+	return verdicts
+}
+
+type VerdictJSON struct {
+	ResultJSON
+	Expected string `json:"expected"`
+	Status   string `json:"status"`
+}
+
+func (v Verdict) JSON() VerdictJSON {
+	return VerdictJSON{ResultJSON: v.RunResult.JSON(), Expected: v.Expected, Status: v.Status}
+}
+
+func printVerdicts(verdicts []Verdict) {
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "DAY\tPART\tINPUT\tEXPECTED\tGOT\tSTATUS\t")
+	for _, v := range verdicts {
+		fmt.Fprintf(
+			tw,
+			"%v\t%v\t%v\t%v\t%v\t%v\t\n",
+			v.Day, v.Part, filepath.Base(v.InputFile), formatAnswer(v.Expected), formatAnswer(v.Got), v.Status,
+		)
+	}
+	tw.Flush()
+
+	for _, v := range verdicts {
+		if v.Status == VERIFY_ERROR || v.Status == VERIFY_MISSING {
+			fmt.Fprintf(os.Stderr, "%v %v (%v): %v\n", DayName(v.Day), PartName(v.Part), filepath.Base(v.InputFile), v.Err)
+		}
+	}
+}
+
+func verifyMain(cmd *cobra.Command, args []string) error {
+	record, err := cmd.Flags().GetBool("record")
+	if err != nil {
+		return &FlagError{Flag: "record", Err: err}
+	}
+
+	answersFile, err := locate(cmd, "answers-file", defaultAnswersFile)
+	if err != nil {
+		return err
+	}
+
+	outputsDir, err := locate(cmd, "outputs-dir", defaultOutputsDir)
+	if err != nil {
+		return err
+	}
+
+	workers, err := cmd.Flags().GetInt("jobs")
+	if err != nil {
+		return &FlagError{Flag: "jobs", Err: err}
+	}
+
+	timeout, err := getTimeout(cmd)
+	if err != nil {
+		return err
+	}
+
+	inputsDir, err := getInputsDir(cmd)
+	if err != nil {
+		return err
+	}
+
+	answers, err := LoadAnswers(answersFile)
+	if record && errors.Is(err, fs.ErrNotExist) {
+		logger.Info("Starting a new answers file", "file", answersFile)
+		answers = make(Answers)
+	} else if err != nil {
+		return err
+	}
+
+	jobs, err := VerifyJobs(inputsDir)
+	if err != nil {
+		return err
+	}
+
+	format, err := getFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	failures := 0
+	verdicts := make([]Verdict, 0, len(jobs))
+	for _, res := range RunJobs(cmd.Context(), jobs, workers, timeout) {
+		v := Verify(res, answers, outputsDir)
+		if record && v.Status != VERIFY_ERROR {
+			answers[AnswerKey{Day: v.Day, Part: v.Part, Input: filepath.Base(v.InputFile)}] = v.Got
+			if v.Status != VERIFY_OK {
+				v.Status = VERIFY_RECORDED
+			}
+		}
+		if v.Status == VERIFY_ERROR || v.Status == VERIFY_MISMATCH {
+			failures++
+		}
+		verdicts = append(verdicts, v)
+	}
+	for _, v := range Unchecked(answers, jobs) {
+		failures++
+		verdicts = append(verdicts, v)
+	}
+
+	if format == FORMAT_JSON {
+		vjs := make([]VerdictJSON, len(verdicts))
+		for i, v := range verdicts {
+			vjs[i] = v.JSON()
+		}
+		err = writeJSON(os.Stdout, vjs)
+		if err != nil {
+			return err
+		}
+	} else {
+		printVerdicts(verdicts)
+	}
+
+	if record {
+		err = answers.Save(answersFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	if failures > 0 {
+		return fmt.Errorf("%v of %v checks failed", failures, len(verdicts))
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package cmd_test
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/dusktreader/advent-of-code-2024/cmd"
+	"github.com/dusktreader/advent-of-code-2024/util"
+	"github.com/spf13/cobra"
+)
+
+func TestAnswersRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.json")
+
+	want := cmd.Answers{
+		{Day: 2, Part: 1, Input: "day02.txt"}:        "463",
+		{Day: 2, Part: 2, Input: "day02.txt"}:        "514",
+		{Day: 6, Part: 1, Input: "day06_sample.txt"}: "41",
+	}
+	err := want.Save(path)
+	util.Unexpect(t, err)
+
+	got, err := cmd.LoadAnswers(path)
+	util.Unexpect(t, err)
+
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("Answers didn't survive a round trip: want %+v, got %+v", want, got)
+	}
+
+	_, err = cmd.LoadAnswers(filepath.Join(t.TempDir(), "missing.json"))
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Missing answers file should be an error, got %v", err)
+	}
+}
+
+// Moves into dir for the rest of the test
+func chdir(t *testing.T, dir string) {
+	wd, err := os.Getwd()
+	util.Unexpect(t, err)
+	err = os.Chdir(dir)
+	util.Unexpect(t, err)
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+}
+
+func TestLocate(t *testing.T) {
+	root := t.TempDir()
+	err := os.WriteFile(filepath.Join(root, "answers.json"), []byte("[]\n"), 0644)
+	util.Unexpect(t, err)
+	nested := filepath.Join(root, "cmd", "deeper")
+	err = os.MkdirAll(nested, 0755)
+	util.Unexpect(t, err)
+	chdir(t, nested)
+
+	c := &cobra.Command{}
+	c.Flags().String("answers-file", "", "")
+
+	got, err := cmd.Locate(c, "answers-file", "answers.json")
+	util.Unexpect(t, err)
+	if want := filepath.Join(root, "answers.json"); got != want {
+		t.Errorf("Answers file should be found in a parent directory: want %v, got %v", want, got)
+	}
+
+	got, err = cmd.Locate(c, "answers-file", "missing.json")
+	util.Unexpect(t, err)
+	if want := filepath.Join(nested, "missing.json"); got != want {
+		t.Errorf("A file that's nowhere should default to the working directory: want %v, got %v", want, got)
+	}
+
+	err = c.Flags().Set("answers-file", "elsewhere.json")
+	util.Unexpect(t, err)
+	got, err = cmd.Locate(c, "answers-file", "answers.json")
+	util.Unexpect(t, err)
+	if got != "elsewhere.json" {
+		t.Errorf("An explicit answers file should be used as is, got %v", got)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	outputsDir := t.TempDir()
+	err := os.WriteFile(filepath.Join(outputsDir, "day09_sample_part2.txt"), []byte("2858\n"), 0644)
+	util.Unexpect(t, err)
+
+	answers := cmd.Answers{
+		{Day: 9, Part: 1, Input: "day09_sample.txt"}: "1928",
+	}
+
+	cases := []struct{
+		res  cmd.RunResult
+		want string
+	}{
+		{cmd.RunResult{Day: 9, Part: 1, InputFile: "inputs/day09_sample.txt", Answer: 1928}, cmd.VERIFY_OK},
+		{cmd.RunResult{Day: 9, Part: 1, InputFile: "inputs/day09_sample.txt", Answer: 1929}, cmd.VERIFY_MISMATCH},
+		{cmd.RunResult{Day: 9, Part: 2, InputFile: "inputs/day09_sample.txt", Answer: 2858}, cmd.VERIFY_OK},
+		{cmd.RunResult{Day: 9, Part: 1, InputFile: "inputs/day09.txt", Answer: 1}, cmd.VERIFY_NEW},
+		{cmd.RunResult{Day: 9, Part: 1, InputFile: "inputs/day09.txt", Err: fmt.Errorf("Boom")}, cmd.VERIFY_ERROR},
+	}
+	for _, c := range cases {
+		got := cmd.Verify(c.res, answers, outputsDir)
+		if got.Status != c.want {
+			t.Errorf("Wrong status for %+v: want %v, got %v", c.res, c.want, got.Status)
+		}
+	}
+}
+
+func TestVerifyJobs(t *testing.T) {
+	_, err := cmd.VerifyJobs(t.TempDir())
+	if cmd.ExitCode(err) != cmd.EXIT_INPUT {
+		t.Errorf("Expected an input error when there are no inputs, got %v", err)
+	}
+}
+
+func TestUnchecked(t *testing.T) {
+	s, err := cmd.GetSolver(9)
+	util.Unexpect(t, err)
+
+	answers := cmd.Answers{
+		{Day: 9, Part: 1, Input: "day09_sample.txt"}: "1928",
+		{Day: 9, Part: 2, Input: "day09_sample.txt"}: "2858",
+		{Day: 9, Part: 1, Input: "day09.txt"}:        "1",
+	}
+	jobs := []cmd.RunJob{
+		{Solver: s, Part: 1, InputFile: "inputs/day09_sample.txt"},
+		{Solver: s, Part: 2, InputFile: "inputs/day09_sample.txt"},
+	}
+
+	got := cmd.Unchecked(answers, jobs)
+	if len(got) != 1 || got[0].Status != cmd.VERIFY_MISSING || got[0].InputFile != "day09.txt" || got[0].Part != 1 {
+		t.Errorf("Expected only the day09.txt answer to be unchecked, got %+v", got)
+	}
+}
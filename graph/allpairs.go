@@ -0,0 +1,170 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// Shortest distances between every pair of nodes along with what is needed to rebuild the paths
+type DistMatrix[T comparable] struct {
+	nodes []T
+	idx   map[T]int
+	dist  [][]int
+	prev  [][]int
+	found [][]bool
+}
+
+func makeDistMatrix[T comparable](nodes []T) *DistMatrix[T] {
+	dm := &DistMatrix[T]{
+		nodes: nodes,
+		idx:   make(map[T]int, len(nodes)),
+		dist:  make([][]int, len(nodes)),
+		prev:  make([][]int, len(nodes)),
+		found: make([][]bool, len(nodes)),
+	}
+	for i, n := range nodes {
+		dm.idx[n] = i
+		dm.dist[i] = make([]int, len(nodes))
+		dm.prev[i] = make([]int, len(nodes))
+		dm.found[i] = make([]bool, len(nodes))
+		dm.found[i][i] = true
+		dm.prev[i][i] = i
+	}
+	return dm
+}
+
+// The shortest distance from a to b. Not ok if there is no path
+func (dm *DistMatrix[T]) Get(a T, b T) (int, bool) {
+	i, iok := dm.idx[a]
+	j, jok := dm.idx[b]
+	if !iok || !jok || !dm.found[i][j] {
+		return 0, false
+	}
+	return dm.dist[i][j], true
+}
+
+// One of the shortest paths from a to b. Not ok if there is no path
+func (dm *DistMatrix[T]) Path(a T, b T) ([]T, bool) {
+	i, iok := dm.idx[a]
+	j, jok := dm.idx[b]
+	if !iok || !jok || !dm.found[i][j] {
+		return nil, false
+	}
+
+	path := []T{b}
+	for j != i {
+		j = dm.prev[i][j]
+		path = append(path, dm.nodes[j])
+	}
+	slices.Reverse(path)
+	return path, true
+}
+
+// The distance from a to every node it can reach
+func (dm *DistMatrix[T]) From(a T) map[T]int {
+	dists := make(map[T]int)
+	i, ok := dm.idx[a]
+	if !ok {
+		return dists
+	}
+	for j, n := range dm.nodes {
+		if dm.found[i][j] {
+			dists[n] = dm.dist[i][j]
+		}
+	}
+	return dists
+}
+
+// Finds every shortest distance. Floyd-Warshall is used for small dense graphs and for negative weights,
+// which Dijkstra can't handle. Otherwise Dijkstra is run from every node
+func (g *Graph[T]) AllPairs(ctx context.Context) (*DistMatrix[T], error) {
+	v := g.nodes.Size()
+	e := g.edges.Size()
+	if !g.directed {
+		e *= 2
+	}
+
+	negative := false
+	for edge := range g.edges.Iter() {
+		negative = negative || edge.Wt < 0
+	}
+
+	if negative || v <= 100 || e * 4 >= v * v {
+		logger.Debug("Finding all pairs with Floyd-Warshall", "nodes", v, "edges", e, "negative", negative)
+		return g.FloydWarshall(ctx)
+	}
+	logger.Debug("Finding all pairs with Dijkstra", "nodes", v, "edges", e)
+	return g.AllDijkstra(ctx)
+}
+
+// Takes O(V^3) time and O(V^2) space whatever the edge count. Handles negative weights, but not cycles
+// that add up to a negative weight
+func (g *Graph[T]) FloydWarshall(ctx context.Context) (*DistMatrix[T], error) {
+	dm := makeDistMatrix(g.nodes.Items())
+	for i, n := range dm.nodes {
+		for edge := range g.adj(n) {
+			j := dm.idx[edge.To]
+			if !dm.found[i][j] || edge.Wt < dm.dist[i][j] {
+				dm.dist[i][j] = edge.Wt
+				dm.prev[i][j] = i
+				dm.found[i][j] = true
+			}
+		}
+	}
+
+	size := len(dm.nodes)
+	for k := 0; k < size; k++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		for i := 0; i < size; i++ {
+			if !dm.found[i][k] {
+				continue
+			}
+			for j := 0; j < size; j++ {
+				if !dm.found[k][j] {
+					continue
+				}
+				through := dm.dist[i][k] + dm.dist[k][j]
+				if !dm.found[i][j] || through < dm.dist[i][j] {
+					dm.dist[i][j] = through
+					dm.prev[i][j] = dm.prev[k][j]
+					dm.found[i][j] = true
+				}
+			}
+		}
+	}
+
+	for i, n := range dm.nodes {
+		if dm.dist[i][i] < 0 {
+			return nil, fmt.Errorf("Found a negative cycle through %v", n)
+		}
+	}
+	return dm, nil
+}
+
+// Takes O(V * E log V) time, which beats Floyd-Warshall when the graph is sparse. Weights can't be negative
+func (g *Graph[T]) AllDijkstra(ctx context.Context) (*DistMatrix[T], error) {
+	dm := makeDistMatrix(g.nodes.Items())
+	for i, n := range dm.nodes {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		dist, preds, err := g.Dijkstra(ctx, n)
+		if err != nil {
+			return nil, err
+		}
+		for m, d := range dist {
+			j := dm.idx[m]
+			dm.dist[i][j] = d
+			dm.found[i][j] = true
+			for p := range preds.InN(m).Iter() {
+				dm.prev[i][j] = dm.idx[p]
+				break
+			}
+		}
+	}
+	return dm, nil
+}
@@ -0,0 +1,90 @@
+package graph_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/dusktreader/advent-of-code-2024/graph"
+	"github.com/dusktreader/advent-of-code-2024/util"
+)
+
+func TestAllPairs(t *testing.T) {
+	g := graph.MakeDigraph[string]()
+	g.AddEdge("a", "b", 4)
+	g.AddEdge("a", "c", 1)
+	g.AddEdge("c", "b", 2)
+	g.AddEdge("b", "d", 1)
+	g.AddEdge("c", "d", 5)
+	g.AddEdge("d", "a", 3)
+	g.Add("e")
+
+	fw, err := g.FloydWarshall(context.Background())
+	util.Unexpect(t, err)
+	dj, err := g.AllDijkstra(context.Background())
+	util.Unexpect(t, err)
+
+	for _, dm := range []*graph.DistMatrix[string]{fw, dj} {
+		want := map[string]int{"a": 0, "b": 3, "c": 1, "d": 4}
+		got := dm.From("a")
+		if !reflect.DeepEqual(want, got) {
+			t.Errorf("Wrong distances: want %v, got %v", want, got)
+		}
+
+		wantPath := []string{"c", "b", "d", "a"}
+		gotPath, ok := dm.Path("c", "a")
+		if !ok || !reflect.DeepEqual(wantPath, gotPath) {
+			t.Errorf("Wrong path: want %v, got %v", wantPath, gotPath)
+		}
+
+		if _, ok := dm.Get("a", "e"); ok {
+			t.Errorf("Shouldn't find a path to an isolated node")
+		}
+		if _, ok := dm.Path("e", "a"); ok {
+			t.Errorf("Shouldn't find a path from an isolated node")
+		}
+	}
+}
+
+func TestAllPairsSparse(t *testing.T) {
+	g := gridGraph(
+		"....................",
+		".##################.",
+		"....................",
+		"##################..",
+		"....................",
+		"....................",
+	)
+
+	dm, err := g.AllPairs(context.Background())
+	util.Unexpect(t, err)
+
+	got, ok := dm.Get(util.MakePoint(0, 0), util.MakePoint(4, 0))
+	if !ok || got != 40 {
+		t.Errorf("Wrong distance: want 40, got %v", got)
+	}
+
+	path, ok := dm.Path(util.MakePoint(0, 0), util.MakePoint(4, 0))
+	if !ok || len(path) != 41 {
+		t.Errorf("Expected a 40 step path, got %v", path)
+	}
+}
+
+func TestAllPairsNegative(t *testing.T) {
+	g := graph.MakeDigraph[int]()
+	g.AddEdge(1, 2, 3)
+	g.AddEdge(2, 3, -2)
+	g.AddEdge(1, 3, 2)
+
+	dm, err := g.AllPairs(context.Background())
+	util.Unexpect(t, err)
+	if got, _ := dm.Get(1, 3); got != 1 {
+		t.Errorf("Wrong distance: want 1, got %v", got)
+	}
+
+	g.AddEdge(3, 1, -2)
+	_, err = g.AllPairs(context.Background())
+	if err == nil {
+		t.Errorf("Expected an error for a negative cycle")
+	}
+}
@@ -0,0 +1,51 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/dusktreader/advent-of-code-2024/util"
+)
+
+// Estimates the cost from a node to the goal. A* only finds the cheapest path if this never overestimates
+type Heuristic[T comparable] func(node T, goal T) int
+
+func Manhattan(a util.Point, b util.Point) int {
+	return util.AbsInt(a.I - b.I) + util.AbsInt(a.J - b.J)
+}
+
+// Suits grids where diagonal steps cost the same as straight ones
+func Chebyshev(a util.Point, b util.Point) int {
+	return util.MaxI(util.AbsInt(a.I - b.I), util.AbsInt(a.J - b.J))
+}
+
+// Follows parents back from end until it reaches start
+func walkBack[T comparable](parents map[T]T, start T, end T) []T {
+	path := []T{end}
+	for node := end; node != start; {
+		node = parents[node]
+		path = append(path, node)
+	}
+	slices.Reverse(path)
+	return path
+}
+
+// Finds a cheapest path from start to goal, searching the nodes the heuristic says are closest to the goal
+// first. Also reports how many nodes were expanded so heuristics can be compared
+func (g *Graph[T]) AStar(
+	ctx context.Context,
+	start T,
+	goal T,
+	h Heuristic[T],
+) (path []T, cost int, expanded int, err error) {
+	if !g.Has(start) {
+		return nil, 0, 0, fmt.Errorf("Start node %v is not in the graph", start)
+	}
+	return Implicit[T](g.Neighbors).AStar(
+		ctx,
+		start,
+		func(n T) bool { return n == goal },
+		func(n T) int { return h(n, goal) },
+	)
+}
@@ -0,0 +1,100 @@
+package graph_test
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dusktreader/advent-of-code-2024/graph"
+	"github.com/dusktreader/advent-of-code-2024/util"
+)
+
+func gridGraph(rows ...string) *graph.Graph[util.Point] {
+	g := graph.MakeGraph[util.Point](false)
+	for i, row := range rows {
+		for j, rn := range strings.TrimSpace(row) {
+			if rn == '#' {
+				continue
+			}
+			pt := util.MakePoint(i, j)
+			g.Add(pt)
+			if g.Has(pt.Add(util.NORTH)) {
+				g.AddEdge(pt, pt.Add(util.NORTH))
+			}
+			if g.Has(pt.Add(util.WEST)) {
+				g.AddEdge(pt, pt.Add(util.WEST))
+			}
+		}
+	}
+	return g
+}
+
+func TestAStar(t *testing.T) {
+	g := gridGraph(
+		".....",
+		".###.",
+		"...#.",
+		"##.#.",
+		".....",
+	)
+	start := util.MakePoint(0, 0)
+	goal := util.MakePoint(4, 0)
+
+	wantCost := 8
+	wantPath := []util.Point{
+		{I: 0, J: 0}, {I: 1, J: 0}, {I: 2, J: 0}, {I: 2, J: 1}, {I: 2, J: 2},
+		{I: 3, J: 2}, {I: 4, J: 2}, {I: 4, J: 1}, {I: 4, J: 0},
+	}
+	path, cost, expanded, err := g.AStar(context.Background(), start, goal, graph.Manhattan)
+	util.Unexpect(t, err)
+	if cost != wantCost {
+		t.Errorf("Wrong cost: want %v, got %v", wantCost, cost)
+	}
+	if !reflect.DeepEqual(path, wantPath) {
+		t.Errorf("Wrong path: want %v, got %v", wantPath, path)
+	}
+
+	_, blindCost, blindExpanded, err := g.AStar(context.Background(), start, goal, func(util.Point, util.Point) int { return 0 })
+	util.Unexpect(t, err)
+	if blindCost != wantCost {
+		t.Errorf("Wrong cost without a heuristic: want %v, got %v", wantCost, blindCost)
+	}
+	if expanded >= blindExpanded {
+		t.Errorf("Manhattan should expand fewer nodes than no heuristic: %v vs %v", expanded, blindExpanded)
+	}
+
+	_, cost, _, err = g.AStar(context.Background(), start, goal, graph.Chebyshev)
+	util.Unexpect(t, err)
+	if cost != wantCost {
+		t.Errorf("Wrong cost with Chebyshev: want %v, got %v", wantCost, cost)
+	}
+}
+
+func TestAStarFail(t *testing.T) {
+	g := gridGraph(
+		".#.",
+		"##.",
+	)
+
+	_, _, _, err := g.AStar(context.Background(), util.MakePoint(0, 0), util.MakePoint(1, 2), graph.Manhattan)
+	if err == nil {
+		t.Errorf("Expected an error for an unreachable goal")
+	}
+
+	_, _, _, err = g.AStar(context.Background(), util.MakePoint(1, 0), util.MakePoint(1, 2), graph.Manhattan)
+	if err == nil {
+		t.Errorf("Expected an error for a missing start")
+	}
+}
+
+func TestHeuristics(t *testing.T) {
+	a := util.MakePoint(1, 2)
+	b := util.MakePoint(4, -2)
+	if got := graph.Manhattan(a, b); got != 7 {
+		t.Errorf("Wrong Manhattan distance: want 7, got %v", got)
+	}
+	if got := graph.Chebyshev(a, b); got != 4 {
+		t.Errorf("Wrong Chebyshev distance: want 4, got %v", got)
+	}
+}
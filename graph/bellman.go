@@ -0,0 +1,148 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/dusktreader/advent-of-code-2024/util"
+)
+
+// Returned when a negative cycle can be reached from the source. Affected holds every node whose distance
+// has no lower bound, and Cycle is one of the cycles to blame, with its first node repeated at the end
+type NegativeCycleError[T comparable] struct {
+	Affected util.Set[T]
+	Cycle    []T
+}
+
+func (e *NegativeCycleError[T]) Error() string {
+	return fmt.Sprintf("Found a negative cycle %v affecting %v nodes", e.Cycle, e.Affected.Size())
+}
+
+// Every edge in the direction it can be taken, so undirected edges show up both ways
+func (g *Graph[T]) arcs() []Edge[T] {
+	arcs := make([]Edge[T], 0, g.edges.Size())
+	for edge := range g.edges.Iter() {
+		arcs = append(arcs, edge)
+		if !g.directed && edge.From != edge.To {
+			arcs = append(arcs, edge.Rev())
+		}
+	}
+	return arcs
+}
+
+// Shortest distances from a that allow negative weights. Parents hold the last step of each shortest path.
+// If a negative cycle can be reached the error is a *NegativeCycleError[T] and dist only holds the nodes
+// that aren't affected by it
+func (g *Graph[T]) BellmanFord(a T) (dist map[T]int, parents map[T]T, err error) {
+	if !g.Has(a) {
+		return nil, nil, fmt.Errorf("Start node %v is not in the graph", a)
+	}
+
+	dist = map[T]int{a: 0}
+	parents = make(map[T]T)
+	arcs := g.arcs()
+
+	relax := func() []T {
+		relaxed := make([]T, 0)
+		for _, arc := range arcs {
+			from, ok := dist[arc.From]
+			if !ok {
+				continue
+			}
+			if to, ok := dist[arc.To]; !ok || from + arc.Wt < to {
+				dist[arc.To] = from + arc.Wt
+				parents[arc.To] = arc.From
+				relaxed = append(relaxed, arc.To)
+			}
+		}
+		return relaxed
+	}
+
+	for range g.nodes.Size() - 1 {
+		if len(relax()) == 0 {
+			return dist, parents, nil
+		}
+	}
+
+	relaxed := relax()
+	if len(relaxed) == 0 {
+		return dist, parents, nil
+	}
+	return g.negativeCycle(dist, parents, relaxed)
+}
+
+// Shortest distances from a that allow negative weights, using a queue so only nodes whose distance just
+// changed are relaxed again. Usually much faster than BellmanFord. When it finds a negative cycle it falls
+// back to BellmanFord for the full report
+func (g *Graph[T]) SPFA(a T) (dist map[T]int, parents map[T]T, err error) {
+	if !g.Has(a) {
+		return nil, nil, fmt.Errorf("Start node %v is not in the graph", a)
+	}
+
+	dist = map[T]int{a: 0}
+	parents = make(map[T]T)
+	steps := map[T]int{a: 0}
+	queued := util.MakeSet(a)
+	queue := util.MakeQueue[T]()
+	queue.Push(a)
+
+	for queue.Size() > 0 {
+		node, _ := queue.Pop()
+		queued.Rem(node)
+
+		for edge := range g.adj(node) {
+			if to, ok := dist[edge.To]; ok && dist[node] + edge.Wt >= to {
+				continue
+			}
+			dist[edge.To] = dist[node] + edge.Wt
+			parents[edge.To] = node
+
+			// A shortest path can't have more edges than there are nodes unless it loops
+			steps[edge.To] = steps[node] + 1
+			if steps[edge.To] >= g.nodes.Size() {
+				logger.Debug("SPFA found a negative cycle", "node", edge.To)
+				return g.BellmanFord(a)
+			}
+
+			if !queued.Has(edge.To) {
+				queued.Add(edge.To)
+				queue.Push(edge.To)
+			}
+		}
+	}
+	return dist, parents, nil
+}
+
+// Builds the error for a negative cycle from the nodes that could still be relaxed after every shortest
+// path should have been found
+func (g *Graph[T]) negativeCycle(dist map[T]int, parents map[T]T, relaxed []T) (map[T]int, map[T]T, error) {
+	// Any node that can still be relaxed is on a negative cycle or downstream of one. Stepping back through
+	// parents as many times as there are nodes is sure to land on the cycle
+	node := relaxed[0]
+	for range g.nodes.Size() {
+		node = parents[node]
+	}
+
+	cycle := []T{node}
+	for prev := parents[node]; prev != node; prev = parents[prev] {
+		cycle = append(cycle, prev)
+	}
+	cycle = append(cycle, node)
+	slices.Reverse(cycle)
+
+	// Only a plain walk over a finite graph is left, so there's nothing worth cancelling
+	reached, err := g.Distances(context.Background(), relaxed...)
+	if err != nil {
+		return nil, nil, err
+	}
+	affected := util.MakeSet[T]()
+	for n := range reached {
+		affected.Add(n)
+	}
+	for n := range affected.Iter() {
+		delete(dist, n)
+		delete(parents, n)
+	}
+	return dist, parents, &NegativeCycleError[T]{Affected: affected, Cycle: cycle}
+}
@@ -0,0 +1,105 @@
+package graph_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/dusktreader/advent-of-code-2024/graph"
+	"github.com/dusktreader/advent-of-code-2024/util"
+)
+
+func TestBellmanFord(t *testing.T) {
+	g := graph.MakeDigraph[string]()
+	g.AddEdge("s", "a", 4)
+	g.AddEdge("s", "b", 5)
+	g.AddEdge("a", "c", 3)
+	g.AddEdge("b", "a", -3)
+	g.AddEdge("c", "d", 2)
+	g.AddEdge("b", "d", 8)
+	g.Add("x")
+
+	want := map[string]int{"s": 0, "a": 2, "b": 5, "c": 5, "d": 7}
+	wantParents := map[string]string{"a": "b", "b": "s", "c": "a", "d": "c"}
+	for name, search := range map[string]func(string) (map[string]int, map[string]string, error){
+		"BellmanFord": g.BellmanFord,
+		"SPFA":        g.SPFA,
+	} {
+		dist, parents, err := search("s")
+		util.Unexpect(t, err)
+		if !reflect.DeepEqual(want, dist) {
+			t.Errorf("%v found wrong distances: want %v, got %v", name, want, dist)
+		}
+		if !reflect.DeepEqual(wantParents, parents) {
+			t.Errorf("%v found wrong parents: want %v, got %v", name, wantParents, parents)
+		}
+	}
+}
+
+func TestBellmanFordNegativeCycle(t *testing.T) {
+	g := graph.MakeDigraph[string]()
+	g.AddEdge("s", "a", 1)
+	g.AddEdge("s", "e", 1)
+	g.AddEdge("a", "b", 1)
+	g.AddEdge("b", "c", -3)
+	g.AddEdge("c", "a", 1)
+	g.AddEdge("c", "d", 1)
+	g.AddEdge("e", "f", 1)
+
+	for name, search := range map[string]func(string) (map[string]int, map[string]string, error){
+		"BellmanFord": g.BellmanFord,
+		"SPFA":        g.SPFA,
+	} {
+		dist, _, err := search("s")
+
+		var cycleErr *graph.NegativeCycleError[string]
+		if !errors.As(err, &cycleErr) {
+			t.Fatalf("%v should have found a negative cycle, got %v", name, err)
+		}
+
+		wantAffected := util.MakeSet("a", "b", "c", "d")
+		if !wantAffected.Eq(cycleErr.Affected) {
+			t.Errorf("%v found wrong affected nodes: want %v, got %v", name, wantAffected, cycleErr.Affected)
+		}
+
+		cycle := cycleErr.Cycle
+		if len(cycle) != 4 || cycle[0] != cycle[3] || !util.MakeSet(cycle...).Eq(util.MakeSet("a", "b", "c")) {
+			t.Errorf("%v found a bad witness cycle: %v", name, cycle)
+		}
+		weight := 0
+		for i := 1; i < len(cycle); i++ {
+			edge, ok := g.Edge(cycle[i - 1], cycle[i])
+			if !ok {
+				t.Fatalf("%v found a witness cycle with a missing edge: %v", name, cycle)
+			}
+			weight += edge.Wt
+		}
+		if weight >= 0 {
+			t.Errorf("%v found a witness cycle that isn't negative: %v", name, cycle)
+		}
+
+		wantDist := map[string]int{"s": 0, "e": 1, "f": 2}
+		if !reflect.DeepEqual(wantDist, dist) {
+			t.Errorf("%v found wrong unaffected distances: want %v, got %v", name, wantDist, dist)
+		}
+	}
+}
+
+func TestBellmanFordUndirected(t *testing.T) {
+	g := graph.MakeGraph[int](false)
+	g.AddEdge(1, 2, 2)
+	g.AddEdge(3, 2, 1)
+
+	dist, _, err := g.SPFA(3)
+	util.Unexpect(t, err)
+	want := map[int]int{1: 3, 2: 1, 3: 0}
+	if !reflect.DeepEqual(want, dist) {
+		t.Errorf("Wrong distances: want %v, got %v", want, dist)
+	}
+
+	g.AddEdge(2, 4, -1)
+	_, _, err = g.BellmanFord(1)
+	if err == nil {
+		t.Errorf("A negative undirected edge should be a negative cycle")
+	}
+}
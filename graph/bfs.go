@@ -0,0 +1,138 @@
+package graph
+
+import (
+	"context"
+	"iter"
+	"slices"
+
+	"github.com/dusktreader/advent-of-code-2024/util"
+)
+
+// Records how a breadth first search reached every node it visited. Sources have no parent
+type BFSTree[T comparable] struct {
+	Dist    map[T]int
+	Parents map[T]T
+}
+
+// Follows parents from n back to whichever source reached it first
+func (bt *BFSTree[T]) PathTo(n T) ([]T, bool) {
+	if _, ok := bt.Dist[n]; !ok {
+		return nil, false
+	}
+
+	path := []T{n}
+	for parent, ok := bt.Parents[n]; ok; parent, ok = bt.Parents[parent] {
+		path = append(path, parent)
+	}
+	slices.Reverse(path)
+	return path, true
+}
+
+// Searches out from every source at once, ignoring weights. If stop is given, the search ends at the first
+// node it accepts, which is as near to a source as any other accepted node
+func (im Implicit[T]) BFSFrom(
+	ctx context.Context,
+	sources []T,
+	stop func(T) bool,
+) (tree *BFSTree[T], found T, ok bool, err error) {
+	tree = &BFSTree[T]{Dist: make(map[T]int), Parents: make(map[T]T)}
+	queue := util.MakeQueue[T]()
+	for _, src := range sources {
+		if _, seen := tree.Dist[src]; !seen {
+			tree.Dist[src] = 0
+			queue.Push(src)
+		}
+	}
+
+	for queue.Size() > 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, found, false, err
+		}
+
+		node, _ := queue.Pop()
+		if stop != nil && stop(node) {
+			return tree, node, true, nil
+		}
+
+		for nbor := range im(node) {
+			if _, seen := tree.Dist[nbor]; seen {
+				continue
+			}
+			tree.Dist[nbor] = tree.Dist[node] + 1
+			tree.Parents[nbor] = node
+			queue.Push(nbor)
+		}
+	}
+	return tree, found, false, nil
+}
+
+// The number of steps to every node reachable from any of the sources. Only ends if that is finite
+func (im Implicit[T]) Distances(ctx context.Context, sources ...T) (map[T]int, error) {
+	tree, _, _, err := im.BFSFrom(ctx, sources, nil)
+	if err != nil {
+		return nil, err
+	}
+	return tree.Dist, nil
+}
+
+// Yields the nodes at each distance from the sources, nearest first. Stopping early is fine, so this works
+// on endless graphs too
+func (im Implicit[T]) Layers(sources ...T) iter.Seq2[int, []T] {
+	return func(yield func(int, []T) bool) {
+		seen := util.MakeSet[T]()
+		layer := make([]T, 0, len(sources))
+		for _, src := range sources {
+			if !seen.Has(src) {
+				seen.Add(src)
+				layer = append(layer, src)
+			}
+		}
+
+		for depth := 0; len(layer) > 0; depth++ {
+			if !yield(depth, layer) {
+				return
+			}
+
+			next := make([]T, 0)
+			for _, node := range layer {
+				for nbor := range im(node) {
+					if !seen.Has(nbor) {
+						seen.Add(nbor)
+						next = append(next, nbor)
+					}
+				}
+			}
+			layer = next
+		}
+	}
+}
+
+// The number of steps to every node no more than k steps from any of the sources
+func (im Implicit[T]) Within(k int, sources ...T) map[T]int {
+	dist := make(map[T]int)
+	for depth, layer := range im.Layers(sources...) {
+		if depth > k {
+			break
+		}
+		for _, node := range layer {
+			dist[node] = depth
+		}
+	}
+	return dist
+}
+
+func (g *Graph[T]) BFSFrom(ctx context.Context, sources []T, stop func(T) bool) (*BFSTree[T], T, bool, error) {
+	return Implicit[T](g.Neighbors).BFSFrom(ctx, sources, stop)
+}
+
+func (g *Graph[T]) Distances(ctx context.Context, sources ...T) (map[T]int, error) {
+	return Implicit[T](g.Neighbors).Distances(ctx, sources...)
+}
+
+func (g *Graph[T]) Layers(sources ...T) iter.Seq2[int, []T] {
+	return Implicit[T](g.Neighbors).Layers(sources...)
+}
+
+func (g *Graph[T]) Within(k int, sources ...T) map[T]int {
+	return Implicit[T](g.Neighbors).Within(k, sources...)
+}
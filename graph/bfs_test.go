@@ -0,0 +1,103 @@
+package graph_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dusktreader/advent-of-code-2024/graph"
+	"github.com/dusktreader/advent-of-code-2024/util"
+)
+
+func TestDistances(t *testing.T) {
+	g := graph.MakeGraph(
+		true,
+		util.MakePair(1, 2),
+		util.MakePair(2, 3),
+		util.MakePair(3, 4),
+		util.MakePair(1, 5),
+		util.MakePair(5, 4),
+		util.MakePair(6, 4),
+	)
+
+	want := map[int]int{1: 0, 2: 1, 3: 2, 4: 2, 5: 1}
+	got, err := g.Distances(context.Background(), 1)
+	util.Unexpect(t, err)
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("Wrong distances: want %v, got %v", want, got)
+	}
+
+	want = map[int]int{3: 0, 4: 1, 6: 0}
+	got, err = g.Distances(context.Background(), 3, 6)
+	util.Unexpect(t, err)
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("Wrong multi-source distances: want %v, got %v", want, got)
+	}
+}
+
+func TestBFSFrom(t *testing.T) {
+	g := gridGraph(
+		".....",
+		".###.",
+		"...#.",
+		"##.#.",
+		".....",
+	)
+	exits := []util.Point{util.MakePoint(0, 4), util.MakePoint(4, 0)}
+	isExit := func(pt util.Point) bool { return pt == exits[0] || pt == exits[1] }
+
+	tree, exit, ok, err := g.BFSFrom(context.Background(), []util.Point{util.MakePoint(2, 2)}, isExit)
+	util.Unexpect(t, err)
+	if !ok {
+		t.Fatalf("Expected to find an exit")
+	}
+	if exit != exits[1] {
+		t.Errorf("Wrong exit: want %v, got %v", exits[1], exit)
+	}
+
+	want := []util.Point{{I: 2, J: 2}, {I: 3, J: 2}, {I: 4, J: 2}, {I: 4, J: 1}, {I: 4, J: 0}}
+	got, ok := tree.PathTo(exit)
+	if !ok || !reflect.DeepEqual(want, got) {
+		t.Errorf("Wrong path: want %v, got %v", want, got)
+	}
+
+	_, _, ok, err = g.BFSFrom(context.Background(), []util.Point{util.MakePoint(2, 2)}, func(util.Point) bool { return false })
+	util.Unexpect(t, err)
+	if ok {
+		t.Errorf("Shouldn't find a node nothing accepts")
+	}
+
+	// An endless graph can only be left by giving up
+	ctx, cancel := context.WithTimeout(context.Background(), 10 * time.Millisecond)
+	defer cancel()
+	_, _, _, err = graph.Implicit[util.Point](lattice).BFSFrom(ctx, []util.Point{util.MakePoint(0, 0)}, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the search to time out, got %v", err)
+	}
+}
+
+func TestLayers(t *testing.T) {
+	im := graph.Implicit[util.Point](lattice)
+	origin := util.MakePoint(0, 0)
+
+	for depth, layer := range im.Layers(origin) {
+		want := 4 * depth
+		if depth == 0 {
+			want = 1
+		}
+		if len(layer) != want {
+			t.Errorf("Wrong size for layer %v: want %v, got %v", depth, want, len(layer))
+		}
+		if depth == 5 {
+			break
+		}
+	}
+
+	want := 25
+	got := len(im.Within(3, origin))
+	if want != got {
+		t.Errorf("Wrong count within 3 steps: want %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,197 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/dusktreader/advent-of-code-2024/util"
+)
+
+// Where an undirected graph can be cut. Cuts holds the articulation points, whose removal splits their
+// component. Bridges holds the edges whose removal does the same. Blocks holds the biconnected components,
+// the largest pieces that no single node removal can split. Cut nodes are in more than one block, and
+// nodes without any edges get a block of their own
+type Biconnection[T comparable] struct {
+	Cuts    util.Set[T]
+	Bridges []Edge[T]
+	Blocks  []util.Set[T]
+	disc    map[T]int
+	low     map[T]int
+	parents map[T]Edge[T]
+}
+
+// Finds articulation points, bridges and biconnected components with one depth first search, so it runs in
+// linear time
+func (g *Graph[T]) Biconnect(ctx context.Context) (*Biconnection[T], error) {
+	return g.biconnect(ctx, g.nodes.Items())
+}
+
+// Searches from each root that hasn't been reached yet. Disc holds the order nodes were found in and low holds
+// the earliest found node each subtree can get back to without its tree edge
+func (g *Graph[T]) biconnect(ctx context.Context, roots []T) (*Biconnection[T], error) {
+	if g.directed {
+		return nil, fmt.Errorf("Biconnected components need a non-directed graph")
+	}
+
+	// A maze corridor is one long path through the search, so it is walked with frames instead of recursion.
+	// Skipped is set once the tree edge back to the parent has been passed over, and tree is where the edge
+	// into the node sits on the edge stack
+	type frame struct {
+		node    T
+		edges   []Edge[T]
+		next    int
+		skipped bool
+		tree    int
+	}
+
+	bc := &Biconnection[T]{
+		Cuts:    util.MakeSet[T](),
+		Bridges: make([]Edge[T], 0),
+		Blocks:  make([]util.Set[T], 0),
+		disc:    make(map[T]int),
+		low:     make(map[T]int),
+		parents: make(map[T]Edge[T]),
+	}
+	frames := make([]frame, 0)
+	stack := make([]Edge[T], 0)
+
+	visit := func(n T, skipped bool) {
+		bc.disc[n] = len(bc.disc)
+		bc.low[n] = bc.disc[n]
+		edges := make([]Edge[T], 0)
+		for edge := range g.adj(n) {
+			if edge.To != n {
+				edges = append(edges, edge)
+			}
+		}
+		frames = append(frames, frame{node: n, edges: edges, skipped: skipped, tree: len(stack) - 1})
+	}
+
+	for _, root := range roots {
+		if _, ok := bc.disc[root]; ok {
+			continue
+		}
+		visit(root, true)
+		children := 0
+
+		for len(frames) > 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			top := &frames[len(frames) - 1]
+			node := top.node
+			if top.next < len(top.edges) {
+				edge := top.edges[top.next]
+				top.next++
+
+				// Only one edge back to the parent is the tree edge. Any others are parallel and count as back edges
+				if parent, ok := bc.parents[node]; ok && !top.skipped && edge.To == parent.From {
+					top.skipped = true
+					continue
+				}
+				if disc, ok := bc.disc[edge.To]; !ok {
+					bc.parents[edge.To] = edge
+					if node == root {
+						children++
+					}
+					stack = append(stack, edge)
+					visit(edge.To, false)
+				} else if disc < bc.disc[node] {
+					stack = append(stack, edge)
+					bc.low[node] = min(bc.low[node], disc)
+				}
+				continue
+			}
+
+			frames = frames[:len(frames) - 1]
+			tree, ok := bc.parents[node]
+			if !ok {
+				if len(top.edges) == 0 {
+					bc.Blocks = append(bc.Blocks, util.MakeSet(node))
+				}
+				continue
+			}
+
+			parent := tree.From
+			bc.low[parent] = min(bc.low[parent], bc.low[node])
+			if bc.low[node] > bc.disc[parent] {
+				bc.Bridges = append(bc.Bridges, tree)
+			}
+			if bc.low[node] >= bc.disc[parent] {
+				if parent != root || children > 1 {
+					bc.Cuts.Add(parent)
+				}
+				// The block is the tree edge into this subtree and every edge found after it
+				block := util.MakeSet[T]()
+				for _, edge := range stack[top.tree:] {
+					block.Add(edge.From, edge.To)
+				}
+				stack = stack[:top.tree]
+				bc.Blocks = append(bc.Blocks, block)
+			}
+		}
+	}
+
+	logger.Debug(
+		"Found biconnected components",
+		"blocks", len(bc.Blocks),
+		"cuts", bc.Cuts.Size(),
+		"bridges", len(bc.Bridges),
+	)
+	return bc, nil
+}
+
+// The nodes whose removal splits the component they are in
+func (g *Graph[T]) ArticulationPoints(ctx context.Context) (util.Set[T], error) {
+	bc, err := g.Biconnect(ctx)
+	if err != nil {
+		return util.Set[T]{}, err
+	}
+	return bc.Cuts, nil
+}
+
+// The edges whose removal splits the component they are in
+func (g *Graph[T]) Bridges(ctx context.Context) ([]Edge[T], error) {
+	bc, err := g.Biconnect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return bc.Bridges, nil
+}
+
+// Finds every node and every edge that lies on all paths from a to b, so removing any one of them cuts b
+// off from a. Both come in the order they are passed going from a to b, and a and b themselves are left out
+func (g *Graph[T]) Chokepoints(ctx context.Context, a T, b T) ([]T, []Edge[T], error) {
+	if !g.Has(a) || !g.Has(b) {
+		return nil, nil, fmt.Errorf("Both %v and %v need to be in the graph", a, b)
+	}
+
+	// With the search rooted at a, the path from a to b runs down the tree. A node on it separates them when the
+	// subtree holding b can't get back above it, and a tree edge does when the subtree can't even reach its top
+	bc, err := g.biconnect(ctx, []T{a})
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, ok := bc.disc[b]; !ok {
+		return nil, nil, fmt.Errorf("No path from %v to %v", a, b)
+	}
+
+	nodes := make([]T, 0)
+	edges := make([]Edge[T], 0)
+	for child := b; child != a; {
+		tree := bc.parents[child]
+		parent := tree.From
+		if parent != a && bc.low[child] >= bc.disc[parent] {
+			nodes = append(nodes, parent)
+		}
+		if bc.low[child] > bc.disc[parent] {
+			edges = append(edges, tree)
+		}
+		child = parent
+	}
+	slices.Reverse(nodes)
+	slices.Reverse(edges)
+	return nodes, edges, nil
+}
@@ -0,0 +1,167 @@
+package graph_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/dusktreader/advent-of-code-2024/graph"
+	"github.com/dusktreader/advent-of-code-2024/util"
+)
+
+func TestBiconnect(t *testing.T) {
+	// Two triangles joined by a bridge, with a tail hanging off one and a node off on its own
+	g := graph.MakeGraph[string](false)
+	g.AddEdge("a", "b")
+	g.AddEdge("b", "c")
+	g.AddEdge("c", "a")
+	g.AddEdge("c", "d")
+	g.AddEdge("d", "e")
+	g.AddEdge("e", "f")
+	g.AddEdge("f", "d")
+	g.AddEdge("f", "g")
+	g.Add("z")
+
+	bc, err := g.Biconnect(context.Background())
+	util.Unexpect(t, err)
+
+	wantCuts := util.MakeSet("c", "d", "f")
+	if !wantCuts.Eq(bc.Cuts) {
+		t.Errorf("Wrong articulation points: want %v, got %v", wantCuts, bc.Cuts)
+	}
+
+	wantBridges := util.MakeSet(util.MakePair("c", "d"), util.MakePair("f", "g"))
+	gotBridges := util.MakeSet[util.Pair[string]]()
+	for _, edge := range bc.Bridges {
+		if edge.From > edge.To {
+			edge = edge.Rev()
+		}
+		gotBridges.Add(util.MakePair(edge.From, edge.To))
+	}
+	if !wantBridges.Eq(gotBridges) {
+		t.Errorf("Wrong bridges: want %v, got %v", wantBridges, gotBridges)
+	}
+
+	wantBlocks := []util.Set[string]{
+		util.MakeSet("a", "b", "c"),
+		util.MakeSet("c", "d"),
+		util.MakeSet("d", "e", "f"),
+		util.MakeSet("f", "g"),
+		util.MakeSet("z"),
+	}
+	if len(wantBlocks) != len(bc.Blocks) {
+		t.Fatalf("Wrong block count: want %v, got %v", wantBlocks, bc.Blocks)
+	}
+	OUTER:
+	for _, want := range wantBlocks {
+		for _, got := range bc.Blocks {
+			if want.Eq(got) {
+				continue OUTER
+			}
+		}
+		t.Errorf("Missing block %v in %v", want, bc.Blocks)
+	}
+
+	// A second edge between c and d makes a cycle, so it stops being a bridge
+	g.AddEdge("d", "c", 5)
+	bridges, err := g.Bridges(context.Background())
+	util.Unexpect(t, err)
+	if len(bridges) != 1 {
+		t.Errorf("Only f-g should be a bridge, got %v", bridges)
+	}
+
+	_, err = graph.MakeDigraph[string]().Biconnect(context.Background())
+	if err == nil {
+		t.Errorf("Expected an error for a directed graph")
+	}
+}
+
+func TestChokepoints(t *testing.T) {
+	g := gridGraph(
+		"...#.....",
+		".#.#.###.",
+		"...#...#.",
+		"##.###.#.",
+		".........",
+		".#######.",
+		"....#....",
+	)
+	start := util.MakePoint(0, 0)
+	end := util.MakePoint(6, 0)
+	reaches := func(h *graph.Graph[util.Point]) bool {
+		_, _, ok, err := h.BFSFrom(context.Background(), []util.Point{start}, func(pt util.Point) bool { return pt == end })
+		util.Unexpect(t, err)
+		return ok
+	}
+
+	// Check against removing each node and edge in turn
+	before, err := g.WeakComp(context.Background())
+	util.Unexpect(t, err)
+	want := util.MakeSet[util.Point]()
+	cuts := util.MakeSet[util.Point]()
+	for n := range g.Nodes().Iter() {
+		h := g.Clone()
+		h.Rem(n)
+		if n != start && n != end && !reaches(h) {
+			want.Add(n)
+		}
+		comps, err := h.WeakComp(context.Background())
+		util.Unexpect(t, err)
+		if len(comps) > len(before) {
+			cuts.Add(n)
+		}
+	}
+	wantEdges := 0
+	for pair := range g.Edges().Iter() {
+		h := g.Clone()
+		h.RemEdge(pair.Left, pair.Right)
+		if !reaches(h) {
+			wantEdges++
+		}
+	}
+
+	nodes, edges, err := g.Chokepoints(context.Background(), start, end)
+	util.Unexpect(t, err)
+	if want.Empty() || wantEdges == 0 {
+		t.Fatalf("Test maze should have chokepoints")
+	}
+	if !want.Eq(util.MakeSet(nodes...)) {
+		t.Errorf("Wrong chokepoints: want %v, got %v", want, nodes)
+	}
+	if len(nodes) != want.Size() {
+		t.Errorf("Chokepoints shouldn't repeat: %v", nodes)
+	}
+	if wantEdges != len(edges) {
+		t.Errorf("Wrong choke edges: want %v, got %v", wantEdges, edges)
+	}
+
+	// Going from start to end, each chokepoint is further away than the last
+	dist, err := g.Distances(context.Background(), start)
+	util.Unexpect(t, err)
+	if !slices.IsSortedFunc(nodes, func(a, b util.Point) int { return dist[a] - dist[b] }) {
+		t.Errorf("Chokepoints should be in order from the start: %v", nodes)
+	}
+
+	got, err := g.ArticulationPoints(context.Background())
+	util.Unexpect(t, err)
+	if !cuts.Eq(got) {
+		t.Errorf("Wrong articulation points: want %v, got %v", cuts, got)
+	}
+}
+
+func TestBiconnectLong(t *testing.T) {
+	g := graph.MakeGraph[int](false)
+	size := 100000
+	for i := 1; i < size; i++ {
+		g.AddEdge(i - 1, i)
+	}
+
+	bc, err := g.Biconnect(context.Background())
+	util.Unexpect(t, err)
+	if bc.Cuts.Size() != size - 2 || len(bc.Bridges) != size - 1 || len(bc.Blocks) != size - 1 {
+		t.Errorf(
+			"A chain should have %v cuts, %v bridges and blocks, got %v, %v and %v",
+			size - 2, size - 1, bc.Cuts.Size(), len(bc.Bridges), len(bc.Blocks),
+		)
+	}
+}
@@ -0,0 +1,155 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/dusktreader/advent-of-code-2024/util"
+)
+
+// Returned when a graph isn't bipartite. Cycle has an odd number of edges, with its first node repeated at
+// the end, so it proves the nodes can't be split into two sides
+type OddCycleError[T comparable] struct {
+	Cycle []T
+}
+
+func (e *OddCycleError[T]) Error() string {
+	return fmt.Sprintf("Graph isn't bipartite, found an odd cycle %v", e.Cycle)
+}
+
+// Splits the nodes of an undirected graph into two sides so that every edge joins one side to the other. If
+// that can't be done the error is an *OddCycleError[T]
+func (g *Graph[T]) Bipartite() (left util.Set[T], right util.Set[T], err error) {
+	if g.directed {
+		return left, right, fmt.Errorf("Bipartite sides need a non-directed graph")
+	}
+
+	// Each node's side is the parity of its BFS depth. An edge within one layer closes an odd cycle
+	depth := make(map[T]int)
+	parents := make(map[T]T)
+	for root := range g.nodes.Iter() {
+		if _, ok := depth[root]; ok {
+			continue
+		}
+		depth[root] = 0
+		queue := util.MakeQueue[T]()
+		queue.Push(root)
+		for queue.Size() > 0 {
+			node, _ := queue.Pop()
+			for edge := range g.adj(node) {
+				d, ok := depth[edge.To]
+				if !ok {
+					depth[edge.To] = depth[node] + 1
+					parents[edge.To] = node
+					queue.Push(edge.To)
+				} else if d % 2 == depth[node] % 2 {
+					return left, right, &OddCycleError[T]{Cycle: oddCycle(parents, node, edge.To)}
+				}
+			}
+		}
+	}
+
+	left, right = util.MakeSet[T](), util.MakeSet[T]()
+	for n, d := range depth {
+		if d % 2 == 0 {
+			left.Add(n)
+		} else {
+			right.Add(n)
+		}
+	}
+	return left, right, nil
+}
+
+// Closes the cycle through a BFS tree made by an edge from a to b at the same depth. Both climb together
+// until they meet
+func oddCycle[T comparable](parents map[T]T, a T, b T) []T {
+	start := a
+	up := []T{a}
+	down := []T{b}
+	for a != b {
+		a, b = parents[a], parents[b]
+		up = append(up, a)
+		down = append(down, b)
+	}
+	slices.Reverse(down)
+	return append(append(up, down[1:]...), start)
+}
+
+// Finds a largest set of edges with no node in two of them, using Hopcroft-Karp. Every edge must join a node in
+// left to one that isn't. The result maps each matched node in left to its partner
+func (g *Graph[T]) MaxMatching(ctx context.Context, left util.Set[T]) (map[T]T, error) {
+	if g.directed {
+		return nil, fmt.Errorf("Matching needs a non-directed graph")
+	}
+	for edge := range g.edges.Iter() {
+		if left.Has(edge.From) == left.Has(edge.To) {
+			return nil, fmt.Errorf("Edge %v doesn't cross between the sides", edge)
+		}
+	}
+
+	match := make(map[T]T)
+	partner := make(map[T]T)
+	dist := make(map[T]int)
+	limit := math.MaxInt
+
+	// Layers the left nodes by how many matched edges it takes to reach them from a free left node. Limit is
+	// the length of the shortest augmenting paths, or MaxInt if there are none
+	layer := func() bool {
+		clear(dist)
+		limit = math.MaxInt
+		queue := util.MakeQueue[T]()
+		for n := range left.Iter() {
+			if _, ok := match[n]; !ok && g.Has(n) {
+				dist[n] = 0
+				queue.Push(n)
+			}
+		}
+		for queue.Size() > 0 {
+			node, _ := queue.Pop()
+			if dist[node] >= limit {
+				continue
+			}
+			for edge := range g.adj(node) {
+				next, ok := partner[edge.To]
+				if !ok {
+					limit = min(limit, dist[node] + 1)
+				} else if _, seen := dist[next]; !seen {
+					dist[next] = dist[node] + 1
+					queue.Push(next)
+				}
+			}
+		}
+		return limit < math.MaxInt
+	}
+
+	// Follows the layers down to a free right node and flips the path. Nodes that lead nowhere are dropped from
+	// the layers so they aren't tried again this phase
+	var augment func(node T) bool
+	augment = func(node T) bool {
+		for edge := range g.adj(node) {
+			next, ok := partner[edge.To]
+			if !ok && dist[node] + 1 == limit || ok && dist[next] == dist[node] + 1 && augment(next) {
+				match[node] = edge.To
+				partner[edge.To] = node
+				return true
+			}
+		}
+		dist[node] = math.MaxInt
+		return false
+	}
+
+	for phase := 0; layer(); phase++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		for n := range left.Iter() {
+			if _, ok := match[n]; !ok && dist[n] == 0 && g.Has(n) {
+				augment(n)
+			}
+		}
+		logger.Debug("Finished matching phase", "phase", phase, "size", len(match))
+	}
+	return match, nil
+}
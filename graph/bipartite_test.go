@@ -0,0 +1,101 @@
+package graph_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dusktreader/advent-of-code-2024/graph"
+	"github.com/dusktreader/advent-of-code-2024/util"
+)
+
+func TestBipartite(t *testing.T) {
+	g := gridGraph(
+		"....",
+		".#..",
+		"....",
+	)
+	left, right, err := g.Bipartite()
+	util.Unexpect(t, err)
+	if left.Size() + right.Size() != g.Nodes().Size() {
+		t.Errorf("Sides %v and %v should cover every node", left, right)
+	}
+	for pair := range g.Edges().Iter() {
+		if left.Has(pair.Left) == left.Has(pair.Right) {
+			t.Errorf("Edge %v doesn't cross between the sides", pair)
+		}
+	}
+
+	g.AddEdge(util.MakePoint(0, 0), util.MakePoint(2, 2))
+	_, _, err = g.Bipartite()
+	var odd *graph.OddCycleError[util.Point]
+	if !errors.As(err, &odd) {
+		t.Fatalf("Expected an odd cycle error, got %v", err)
+	}
+
+	cycle := odd.Cycle
+	if len(cycle) % 2 != 0 || cycle[0] != cycle[len(cycle) - 1] {
+		t.Errorf("Cycle %v should close up with an odd number of edges", cycle)
+	}
+	for i := 1; i < len(cycle); i++ {
+		if _, ok := g.Edge(cycle[i - 1], cycle[i]); !ok {
+			t.Errorf("Cycle %v has no edge from %v to %v", cycle, cycle[i - 1], cycle[i])
+		}
+	}
+}
+
+func TestMaxMatching(t *testing.T) {
+	g := graph.MakeGraph[string](false)
+	g.AddEdge("a", "1")
+	g.AddEdge("a", "2")
+	g.AddEdge("b", "1")
+	g.AddEdge("c", "1")
+	g.AddEdge("c", "3")
+	g.AddEdge("d", "2")
+	g.AddEdge("d", "3")
+	g.AddEdge("d", "4")
+	g.AddEdge("e", "3")
+	g.Add("f")
+	left := util.MakeSet("a", "b", "c", "d", "e", "f")
+
+	match, err := g.MaxMatching(context.Background(), left)
+	util.Unexpect(t, err)
+	if len(match) != 4 {
+		t.Errorf("Wrong matching size: want 4, got %v (%v)", len(match), match)
+	}
+
+	used := util.MakeSet[string]()
+	for l, r := range match {
+		if _, ok := g.Edge(l, r); !ok || !left.Has(l) {
+			t.Errorf("%v isn't a left node matched along an edge to %v", l, r)
+		}
+		if used.Has(r) {
+			t.Errorf("%v is matched twice", r)
+		}
+		used.Add(r)
+	}
+
+	_, err = g.MaxMatching(context.Background(), util.MakeSet("a", "1"))
+	if err == nil {
+		t.Errorf("Expected an error when an edge stays within one side")
+	}
+}
+
+func TestMaxMatchingLarge(t *testing.T) {
+	g := graph.MakeGraph[util.Point](false)
+	left := util.MakeSet[util.Point]()
+	size := 200
+	for i := range size {
+		for j := range size {
+			g.AddEdge(util.MakePoint(i, j), util.MakePoint(-1 - i, (j + 1) % size))
+			g.AddEdge(util.MakePoint(i, j), util.MakePoint(-1 - ((i + 1) % size), j))
+			left.Add(util.MakePoint(i, j))
+		}
+	}
+
+	match, err := g.MaxMatching(context.Background(), left)
+	util.Unexpect(t, err)
+	if len(match) != size * size {
+		t.Errorf("Expected a perfect matching of %v, got %v", size * size, len(match))
+	}
+}
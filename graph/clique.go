@@ -0,0 +1,138 @@
+package graph
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/dusktreader/advent-of-code-2024/util"
+)
+
+// Neighbor sets for every node of an undirected graph, leaving out self loops
+func nborSets[T comparable](g *Graph[T]) (map[T]util.Set[T], error) {
+	if g.directed {
+		return nil, fmt.Errorf("Cliques need a non-directed graph")
+	}
+	nbors := make(map[T]util.Set[T], g.nodes.Size())
+	for n := range g.nodes.Iter() {
+		nbors[n] = g.Nbors(n)
+	}
+	return nbors, nil
+}
+
+// Finds every maximal clique, that is every set of nodes that are all joined to each other and can't take
+// another node. Uses Bron-Kerbosch with pivoting. Each clique is sorted and the cliques come in sorted order
+func Cliques[T cmp.Ordered](ctx context.Context, g *Graph[T]) ([][]T, error) {
+	nbors, err := nborSets(g)
+	if err != nil {
+		return nil, err
+	}
+
+	cliques := make([][]T, 0)
+	var extend func(clique []T, cands util.Set[T], done util.Set[T]) error
+	extend = func(clique []T, cands util.Set[T], done util.Set[T]) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if cands.Empty() && done.Empty() {
+			clique = slices.Clone(clique)
+			slices.Sort(clique)
+			cliques = append(cliques, clique)
+			return nil
+		}
+
+		// Any maximal clique holds the pivot or one of its non-neighbors, so only those need to be tried.
+		// Picking the pivot with the most neighbors among the candidates leaves the fewest branches
+		var pivot T
+		best := -1
+		for _, group := range []util.Set[T]{cands, done} {
+			for n := range group.Iter() {
+				if size := cands.Ix(nbors[n]).Size(); size > best {
+					pivot, best = n, size
+				}
+			}
+		}
+
+		for _, n := range cands.Diff(nbors[pivot]).Items() {
+			err := extend(append(clique, n), cands.Ix(nbors[n]), done.Ix(nbors[n]))
+			if err != nil {
+				return err
+			}
+			cands.Rem(n)
+			done.Add(n)
+		}
+		return nil
+	}
+
+	if err := extend(nil, g.nodes.Clone(), util.MakeSet[T]()); err != nil {
+		return nil, err
+	}
+	slices.SortFunc(cliques, slices.Compare)
+	logger.Debug("Found maximal cliques", "count", len(cliques))
+	return cliques, nil
+}
+
+// Finds a largest clique. When several are tied the one that sorts first is returned
+func MaxClique[T cmp.Ordered](ctx context.Context, g *Graph[T]) ([]T, error) {
+	cliques, err := Cliques(ctx, g)
+	if err != nil {
+		return nil, err
+	}
+
+	var largest []T
+	for _, clique := range cliques {
+		if len(clique) > len(largest) {
+			largest = clique
+		}
+	}
+	return largest, nil
+}
+
+// Finds every clique of exactly k nodes, so k=3 finds all the triangles. Each clique is sorted and the
+// cliques come in sorted order
+func KCliques[T cmp.Ordered](ctx context.Context, g *Graph[T], k int) ([][]T, error) {
+	if k < 1 {
+		return nil, fmt.Errorf("Clique size must be positive, got %v", k)
+	}
+	nbors, err := nborSets(g)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cliques are only grown with nodes that sort after the last one, so each is built once and in order
+	cliques := make([][]T, 0)
+	var extend func(clique []T, cands []T) error
+	extend = func(clique []T, cands []T) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if len(clique) == k {
+			cliques = append(cliques, slices.Clone(clique))
+			return nil
+		}
+		for i, n := range cands {
+			next := make([]T, 0, len(cands) - i - 1)
+			for _, m := range cands[i + 1:] {
+				if nbors[n].Has(m) {
+					next = append(next, m)
+				}
+			}
+			if len(clique) + 1 + len(next) < k {
+				continue
+			}
+			if err := extend(append(clique, n), next); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	nodes := g.nodes.Items()
+	slices.Sort(nodes)
+	if err := extend(make([]T, 0, k), nodes); err != nil {
+		return nil, err
+	}
+	logger.Debug("Found cliques", "size", k, "count", len(cliques))
+	return cliques, nil
+}
@@ -0,0 +1,95 @@
+package graph_test
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/dusktreader/advent-of-code-2024/graph"
+	"github.com/dusktreader/advent-of-code-2024/util"
+)
+
+func lanParty() *graph.Graph[string] {
+	links := `
+		kh-tc qp-kh de-cg ka-co yn-aq qp-ub cg-tb vc-aq tb-ka wh-tc yn-cg kh-ub ta-co de-co tc-td tb-wq wh-td
+		ta-ka td-qp aq-cg wq-ub ub-vc de-ta wq-aq wq-vc wh-yn ka-de kh-ta co-tc wh-qp tb-vc td-yn
+	`
+	g := graph.MakeGraph[string](false)
+	for _, link := range strings.Fields(links) {
+		ends := strings.Split(link, "-")
+		g.AddEdge(ends[0], ends[1])
+	}
+	return g
+}
+
+func TestKCliques(t *testing.T) {
+	g := lanParty()
+	triangles, err := graph.KCliques(context.Background(), g, 3)
+	util.Unexpect(t, err)
+
+	want := [][]string{
+		{"aq", "cg", "yn"}, {"aq", "vc", "wq"}, {"co", "de", "ka"}, {"co", "de", "ta"},
+		{"co", "ka", "ta"}, {"de", "ka", "ta"}, {"kh", "qp", "ub"}, {"qp", "td", "wh"},
+		{"tb", "vc", "wq"}, {"tc", "td", "wh"}, {"td", "wh", "yn"}, {"ub", "vc", "wq"},
+	}
+	if !slices.EqualFunc(want, triangles, slices.Equal) {
+		t.Errorf("Wrong triangles:\n  want %v\n  got  %v", want, triangles)
+	}
+
+	pairs, err := graph.KCliques(context.Background(), g, 2)
+	util.Unexpect(t, err)
+	if len(pairs) != g.Edges().Size() {
+		t.Errorf("Every edge should be a 2-clique: want %v, got %v", g.Edges().Size(), len(pairs))
+	}
+
+	_, err = graph.KCliques(context.Background(), g, 0)
+	if err == nil {
+		t.Errorf("Expected an error for an empty clique size")
+	}
+}
+
+func TestCliques(t *testing.T) {
+	g := lanParty()
+	g.Add("zz")
+
+	cliques, err := graph.Cliques(context.Background(), g)
+	util.Unexpect(t, err)
+	if !slices.IsSortedFunc(cliques, slices.Compare) {
+		t.Errorf("Cliques should come in sorted order: %v", cliques)
+	}
+
+	// Every triangle sits inside some maximal clique, and no maximal clique sits inside another
+	triangles, _ := graph.KCliques(context.Background(), g, 3)
+	OUTER:
+	for _, tri := range triangles {
+		for _, clique := range cliques {
+			if util.MakeSet(clique...).Ix(util.MakeSet(tri...)).Size() == 3 {
+				continue OUTER
+			}
+		}
+		t.Errorf("Triangle %v isn't in any maximal clique", tri)
+	}
+	for i, a := range cliques {
+		for j, b := range cliques {
+			if i != j && util.MakeSet(a...).Ix(util.MakeSet(b...)).Size() == len(a) {
+				t.Errorf("Clique %v isn't maximal, it's inside %v", a, b)
+			}
+		}
+	}
+	if !slices.ContainsFunc(cliques, func(c []string) bool { return slices.Equal(c, []string{"zz"}) }) {
+		t.Errorf("A lone node should be a maximal clique on its own")
+	}
+
+	largest, err := graph.MaxClique(context.Background(), g)
+	util.Unexpect(t, err)
+	want := []string{"co", "de", "ka", "ta"}
+	if !slices.Equal(want, largest) {
+		t.Errorf("Wrong largest clique: want %v, got %v", want, largest)
+	}
+
+	_, err = graph.Cliques(context.Background(), graph.MakeDigraph[string]())
+	if err == nil {
+		t.Errorf("Expected an error for a directed graph")
+	}
+}
@@ -0,0 +1,115 @@
+package graph
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/dusktreader/advent-of-code-2024/util"
+)
+
+// A graph with its dead ends pruned and its corridors merged into single weighted edges. Each edge
+// remembers the original nodes it stands for so paths through the contracted graph can be expanded
+type Contraction[T comparable] struct {
+	Graph  *Graph[T]
+	routes map[util.Pair[T]][]T
+}
+
+// The original nodes from a to b that the edge between them stands for, including both ends
+func (c *Contraction[T]) Route(a T, b T) ([]T, bool) {
+	if route, ok := c.routes[util.MakePair(a, b)]; ok {
+		return slices.Clone(route), true
+	}
+	if route, ok := c.routes[util.MakePair(b, a)]; ok {
+		route = slices.Clone(route)
+		slices.Reverse(route)
+		return route, true
+	}
+	if _, ok := c.Graph.Edge(a, b); ok {
+		return []T{a, b}, true
+	}
+	return nil, false
+}
+
+// Turns a path through the contracted graph back into the path through the original graph
+func (c *Contraction[T]) Expand(path []T) ([]T, error) {
+	if len(path) == 0 {
+		return nil, nil
+	}
+
+	full := []T{path[0]}
+	for i := 1; i < len(path); i++ {
+		route, ok := c.Route(path[i - 1], path[i])
+		if !ok {
+			return nil, fmt.Errorf("No contracted edge from %v to %v", path[i - 1], path[i])
+		}
+		full = append(full, route[1:]...)
+	}
+	return full, nil
+}
+
+func (c *Contraction[T]) setRoute(a T, b T, route []T) {
+	delete(c.routes, util.MakePair(b, a))
+	if len(route) == 2 {
+		delete(c.routes, util.MakePair(a, b))
+	} else {
+		c.routes[util.MakePair(a, b)] = route
+	}
+}
+
+func (c *Contraction[T]) rem(n T) {
+	for nbor := range c.Graph.Nbors(n).Iter() {
+		delete(c.routes, util.MakePair(n, nbor))
+		delete(c.routes, util.MakePair(nbor, n))
+	}
+	c.Graph.Rem(n)
+}
+
+// Contracts a copy of an undirected graph. Nodes with one neighbor are pruned over and over, so whole dead
+// end branches go. Then nodes with two neighbors are replaced by an edge whose weight is the sum of the two
+// they joined. Protected nodes are never removed. When two corridors join the same pair of nodes only the
+// cheaper one is kept, which leaves shortest paths unchanged
+func (g *Graph[T]) Contract(protected util.Set[T]) (*Contraction[T], error) {
+	if g.directed {
+		return nil, fmt.Errorf("Can only contract a non-directed graph")
+	}
+
+	c := &Contraction[T]{
+		Graph:  g.Clone(),
+		routes: make(map[util.Pair[T]][]T),
+	}
+
+	work := g.nodes.Clone()
+	for !work.Empty() {
+		node := work.Pop()
+		if protected.Has(node) || !c.Graph.Has(node) {
+			continue
+		}
+
+		nbors := c.Graph.Nbors(node)
+		switch nbors.Size() {
+		case 1:
+			work.Add(nbors.First())
+			c.rem(node)
+		case 2:
+			a := nbors.Pop()
+			b := nbors.Pop()
+			ab, _ := c.Route(a, node)
+			nb, _ := c.Route(node, b)
+			an, _ := c.Graph.Edge(a, node)
+			bn, _ := c.Graph.Edge(node, b)
+			route := append(ab, nb[1:]...)
+			weight := an.Wt + bn.Wt
+
+			if old, ok := c.Graph.Edge(a, b); !ok || weight < old.Wt {
+				c.Graph.RemEdge(a, b)
+				c.Graph.AddEdge(a, b, weight)
+				c.setRoute(a, b, route)
+			}
+			c.rem(node)
+			work.Add(a, b)
+		}
+	}
+
+	logger.Debug("Contracted graph", "before", g.nodes.Size(), "after", c.Graph.nodes.Size())
+	return c, nil
+}
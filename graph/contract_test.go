@@ -0,0 +1,93 @@
+package graph_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dusktreader/advent-of-code-2024/graph"
+	"github.com/dusktreader/advent-of-code-2024/util"
+)
+
+func TestContract(t *testing.T) {
+	g := gridGraph(
+		"......#",
+		".####.#",
+		"......#",
+		"#.###.#",
+		"#.#...#",
+		"#.#.###",
+	)
+	start := util.MakePoint(0, 0)
+	end := util.MakePoint(5, 1)
+
+	c, err := g.Contract(util.MakeSet(start, end))
+	util.Unexpect(t, err)
+
+	// The dead end goes, then the long way around the loop loses to the short way, which leaves a corridor
+	want := util.MakeSet(start, end)
+	got := c.Graph.Nodes()
+	if !want.Eq(got) {
+		t.Errorf("Wrong nodes left: want %v, got %v", want, got)
+	}
+
+	wantWeight, _, err := g.ShortestPaths(context.Background(), start, end)
+	util.Unexpect(t, err)
+	gotWeight, paths, err := c.Graph.ShortestPaths(context.Background(), start, end)
+	util.Unexpect(t, err)
+	if wantWeight != gotWeight {
+		t.Errorf("Contraction changed the distance: want %v, got %v", wantWeight, gotWeight)
+	}
+
+	full, err := c.Expand(paths[0])
+	util.Unexpect(t, err)
+	if len(full) != wantWeight + 1 || full[0] != start || full[len(full) - 1] != end {
+		t.Fatalf("Expanded path has the wrong ends or length: %v", full)
+	}
+	for i := 1; i < len(full); i++ {
+		if _, ok := g.Edge(full[i - 1], full[i]); !ok {
+			t.Errorf("Expanded path takes a step that isn't in the graph: %v to %v", full[i - 1], full[i])
+		}
+	}
+
+	route, ok := c.Route(end, start)
+	if !ok || len(route) != 7 || route[0] != end || route[6] != start {
+		t.Errorf("Wrong route back to the start: %v", route)
+	}
+
+	// Protecting a node on the far side of the loop keeps both ways around
+	far := util.MakePoint(2, 5)
+	c, err = g.Contract(util.MakeSet(start, end, far))
+	util.Unexpect(t, err)
+
+	want = util.MakeSet(start, end, far, util.MakePoint(2, 1))
+	got = c.Graph.Nodes()
+	if !want.Eq(got) {
+		t.Errorf("Wrong nodes left: want %v, got %v", want, got)
+	}
+
+	route, ok = c.Route(far, start)
+	if !ok || len(route) != 8 || route[0] != far || route[7] != start {
+		t.Errorf("Wrong route around the loop: %v", route)
+	}
+}
+
+func TestContractKeepsCheaperCorridor(t *testing.T) {
+	g := graph.MakeGraph[string](false)
+	g.AddEdge("a", "x", 1)
+	g.AddEdge("x", "b", 1)
+	g.AddEdge("a", "y", 5)
+	g.AddEdge("y", "b", 5)
+
+	c, err := g.Contract(util.MakeSet("a", "b"))
+	util.Unexpect(t, err)
+
+	edge, ok := c.Graph.Edge("a", "b")
+	if !ok || edge.Wt != 2 || c.Graph.Edges().Size() != 1 {
+		t.Errorf("Expected just the cheaper corridor, got %v", c.Graph)
+	}
+
+	_, err = graph.MakeDigraph[int]().Contract(util.MakeSet[int]())
+	if err == nil {
+		t.Errorf("Expected an error contracting a digraph")
+	}
+}
@@ -0,0 +1,54 @@
+package graph
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/dusktreader/advent-of-code-2024/util"
+)
+
+// Counts the paths from any node in from to any node in to without listing them. Each node's count is the
+// sum of its in-neighbors' counts, so one pass over the topological sort covers every pair. Counts grow
+// exponentially with the size of the graph, so they are big.Ints
+func (g *Graph[T]) CountPathsBetween(from util.Set[T], to util.Set[T]) (*big.Int, error) {
+	if g.directed == false {
+		return nil, fmt.Errorf("Can't count paths in a non-directed graph")
+	}
+
+	sorted, err := g.GetTopo()
+	if err != nil {
+		return nil, util.ReErr(err, "Can only count paths in a DAG")
+	}
+
+	counts := make(map[T]*big.Int, len(sorted))
+	total := big.NewInt(0)
+	for _, n := range sorted {
+		count, ok := counts[n]
+		if !ok {
+			count = big.NewInt(0)
+		}
+		if from.Has(n) {
+			count.Add(count, big.NewInt(1))
+		}
+		if count.Sign() == 0 {
+			continue
+		}
+		if to.Has(n) {
+			total.Add(total, count)
+		}
+
+		for edge := range g.adj(n) {
+			next, ok := counts[edge.To]
+			if !ok {
+				next = big.NewInt(0)
+				counts[edge.To] = next
+			}
+			next.Add(next, count)
+		}
+	}
+	return total, nil
+}
+
+func (g *Graph[T]) CountPaths(a T, b T) (*big.Int, error) {
+	return g.CountPathsBetween(util.MakeSet(a), util.MakeSet(b))
+}
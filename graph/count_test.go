@@ -0,0 +1,82 @@
+package graph_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/dusktreader/advent-of-code-2024/graph"
+	"github.com/dusktreader/advent-of-code-2024/util"
+)
+
+func TestCountPaths(t *testing.T) {
+	g := graph.MakeGraph(
+		true,
+		util.MakePair(1, 2),
+		util.MakePair(1, 3),
+		util.MakePair(1, 5),
+		util.MakePair(4, 5),
+		util.MakePair(5, 2),
+		util.MakePair(2, 3),
+		util.MakePair(5, 6),
+	)
+
+	got, err := g.CountPaths(1, 3)
+	util.Unexpect(t, err)
+	if got.Cmp(big.NewInt(3)) != 0 {
+		t.Errorf("Wrong path count: want 3, got %v", got)
+	}
+
+	got, err = g.CountPaths(3, 1)
+	util.Unexpect(t, err)
+	if got.Sign() != 0 {
+		t.Errorf("Wrong path count: want 0, got %v", got)
+	}
+
+	got, err = g.CountPathsBetween(util.MakeSet(1, 4), util.MakeSet(3, 6))
+	util.Unexpect(t, err)
+	if got.Cmp(big.NewInt(6)) != 0 {
+		t.Errorf("Wrong path count: want 6, got %v", got)
+	}
+}
+
+func TestCountPathsBig(t *testing.T) {
+	// Each diamond doubles the paths, so 100 of them is far more than an int can hold
+	g := graph.MakeDigraph[int]()
+	n := 100
+	for i := 0; i < n; i++ {
+		g.AddEdge(3 * i, 3 * i + 1)
+		g.AddEdge(3 * i, 3 * i + 2)
+		g.AddEdge(3 * i + 1, 3 * i + 3)
+		g.AddEdge(3 * i + 2, 3 * i + 3)
+	}
+
+	want := new(big.Int).Lsh(big.NewInt(1), uint(n))
+	got, err := g.CountPaths(0, 3 * n)
+	util.Unexpect(t, err)
+	if got.Cmp(want) != 0 {
+		t.Errorf("Wrong path count: want %v, got %v", want, got)
+	}
+}
+
+func TestCountPathsFail(t *testing.T) {
+	g := graph.MakeGraph(true, util.MakePair(1, 2), util.MakePair(2, 1))
+	_, err := g.CountPaths(1, 2)
+	if err == nil {
+		t.Errorf("Expected an error counting paths in a cyclic digraph")
+	}
+
+	// A failed sort mustn't be cached and handed out on the next call
+	g = graph.MakeGraph(true, util.MakePair(1, 2), util.MakePair(2, 3), util.MakePair(3, 2), util.MakePair(3, 4))
+	for i := range 2 {
+		_, err = g.CountPaths(1, 4)
+		if err == nil {
+			t.Errorf("Expected an error counting paths through a cycle on call %v", i + 1)
+		}
+	}
+
+	g = graph.MakeGraph(false, util.MakePair(1, 2))
+	_, err = g.CountPaths(1, 2)
+	if err == nil {
+		t.Errorf("Expected an error counting paths in an undirected graph")
+	}
+}
@@ -0,0 +1,236 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+// A residual network on node indexes. Arc i and arc i^1 are each other's reverse, so pushing flow along one
+// frees up capacity on the other
+type flowNet struct {
+	adj   [][]int
+	to    []int
+	cap   []int
+	orig  []int
+	level []int
+	next  []int
+}
+
+func makeFlowNet(size int) *flowNet {
+	return &flowNet{
+		adj:   make([][]int, size),
+		level: make([]int, size),
+		next:  make([]int, size),
+	}
+}
+
+func (fn *flowNet) addArc(u int, v int, capacity int) int {
+	arc := len(fn.to)
+	fn.to = append(fn.to, v, u)
+	fn.cap = append(fn.cap, capacity, 0)
+	fn.orig = append(fn.orig, capacity, 0)
+	fn.adj[u] = append(fn.adj[u], arc)
+	fn.adj[v] = append(fn.adj[v], arc ^ 1)
+	return arc
+}
+
+func (fn *flowNet) flow(arc int) int {
+	return fn.orig[arc] - fn.cap[arc]
+}
+
+// Labels each node with its distance from s over arcs that still have capacity. False if t can't be reached
+func (fn *flowNet) levels(s int, t int) bool {
+	for i := range fn.level {
+		fn.level[i] = -1
+	}
+	fn.level[s] = 0
+	queue := []int{s}
+	for len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+		for _, arc := range fn.adj[u] {
+			v := fn.to[arc]
+			if fn.cap[arc] > 0 && fn.level[v] < 0 {
+				fn.level[v] = fn.level[u] + 1
+				queue = append(queue, v)
+			}
+		}
+	}
+	return fn.level[t] >= 0
+}
+
+// Pushes up to limit along one path that only goes a level deeper each step. Arcs that turn out to be
+// dead ends are skipped for the rest of the phase
+func (fn *flowNet) push(u int, t int, limit int) int {
+	if u == t {
+		return limit
+	}
+	for ; fn.next[u] < len(fn.adj[u]); fn.next[u]++ {
+		arc := fn.adj[u][fn.next[u]]
+		v := fn.to[arc]
+		if fn.cap[arc] <= 0 || fn.level[v] != fn.level[u] + 1 {
+			continue
+		}
+		if pushed := fn.push(v, t, min(limit, fn.cap[arc])); pushed > 0 {
+			fn.cap[arc] -= pushed
+			fn.cap[arc ^ 1] += pushed
+			return pushed
+		}
+	}
+	return 0
+}
+
+// Dinic's algorithm. Each phase finds a blocking flow in the level graph, and there are at most V phases
+func (fn *flowNet) dinic(ctx context.Context, s int, t int) (int, error) {
+	total := 0
+	for fn.levels(s, t) {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		for i := range fn.next {
+			fn.next[i] = 0
+		}
+		for pushed := fn.push(s, t, math.MaxInt); pushed > 0; pushed = fn.push(s, t, math.MaxInt) {
+			total += pushed
+		}
+	}
+	return total, nil
+}
+
+// Nodes still reachable from s once the flow is at its max. They make up the source side of a minimum cut
+func (fn *flowNet) sourceSide(s int) []bool {
+	fn.levels(s, s)
+	side := make([]bool, len(fn.adj))
+	for i, lvl := range fn.level {
+		side[i] = lvl >= 0
+	}
+	return side
+}
+
+// More than any real capacities can add up to
+const unbounded = math.MaxInt / 2
+
+// The result of a max flow search. Flows holds the flow along each edge that carries any, in the direction it
+// goes. Cut holds the edges of a minimum cut. With node capacities only nodes are cut, so CutNodes holds them
+// and Cut is empty
+type Flow[T comparable] struct {
+	Value    int
+	Flows    map[Edge[T]]int
+	Cut      []Edge[T]
+	CutNodes []T
+}
+
+// Finds the most flow that can get from s to t, using edge weights as capacities. The flow value is also the
+// total weight of the lightest set of edges that cuts t off from s. Undirected edges carry flow either way
+func (g *Graph[T]) MaxFlow(ctx context.Context, s T, t T) (*Flow[T], error) {
+	return g.maxFlow(ctx, s, t, nil)
+}
+
+// Like MaxFlow, but the capacities are on the nodes instead. Each node other than s and t can only pass on as
+// much flow as capacity allows, and edges carry any amount. With unit capacities the flow value is how many
+// nodes need to be removed to cut t off from s. It's an error if s and t are neighbors, since then no nodes can
+func (g *Graph[T]) MaxFlowNodes(ctx context.Context, s T, t T, capacity func(T) int) (*Flow[T], error) {
+	return g.maxFlow(ctx, s, t, capacity)
+}
+
+func (g *Graph[T]) maxFlow(ctx context.Context, s T, t T, capacity func(T) int) (*Flow[T], error) {
+	if !g.Has(s) || !g.Has(t) {
+		return nil, fmt.Errorf("Both %v and %v need to be in the graph", s, t)
+	} else if s == t {
+		return nil, fmt.Errorf("Source and sink are both %v", s)
+	}
+
+	nodes := g.nodes.Items()
+	idx := make(map[T]int, len(nodes))
+	for i, n := range nodes {
+		idx[n] = i
+	}
+
+	// With node capacities each node splits into an in half and an out half joined by an arc that holds the
+	// node's capacity. Otherwise both halves are the same node
+	in := func(i int) int { return i }
+	out := func(i int) int { return i }
+	size := len(nodes)
+	nodeArcs := make(map[int]T)
+	if capacity != nil {
+		in = func(i int) int { return 2 * i }
+		out = func(i int) int { return 2 * i + 1 }
+		size *= 2
+	}
+
+	fn := makeFlowNet(size)
+	if capacity != nil {
+		for i, n := range nodes {
+			nodeCap := unbounded
+			if n != s && n != t {
+				nodeCap = capacity(n)
+			}
+			if nodeCap < 0 {
+				return nil, fmt.Errorf("Capacity can't be negative on node %v", n)
+			}
+			nodeArcs[fn.addArc(in(i), out(i), nodeCap)] = n
+		}
+	}
+
+	edgeArcs := make(map[int]Edge[T])
+	for edge := range g.edges.Iter() {
+		edgeCap := edge.Wt
+		if capacity != nil {
+			edgeCap = unbounded
+		}
+		if edgeCap < 0 {
+			return nil, fmt.Errorf("Capacity can't be negative on edge %v", edge)
+		} else if edge.From == edge.To {
+			continue
+		}
+		u, v := idx[edge.From], idx[edge.To]
+		edgeArcs[fn.addArc(out(u), in(v), edgeCap)] = edge
+		if !g.directed {
+			edgeArcs[fn.addArc(out(v), in(u), edgeCap)] = edge.Rev()
+		}
+	}
+
+	// Starting before s and ending after t caps the flow at unbounded, so an edge straight from s to t shows up
+	// as a flow that big
+	value, err := fn.dinic(ctx, in(idx[s]), out(idx[t]))
+	if err != nil {
+		return nil, err
+	} else if capacity != nil && value >= unbounded {
+		return nil, fmt.Errorf("No nodes can cut %v off from its neighbor %v", t, s)
+	}
+	logger.Debug("Found max flow", "from", s, "to", t, "value", value)
+
+	flow := &Flow[T]{Value: value, Flows: make(map[Edge[T]]int)}
+	for arc, edge := range edgeArcs {
+		if f := fn.flow(arc); f > 0 {
+			flow.Flows[edge] += f
+		}
+	}
+	if !g.directed {
+		// Flow both ways along an undirected edge cancels out
+		for edge, f := range flow.Flows {
+			rf := flow.Flows[edge.Rev()]
+			if f > rf {
+				flow.Flows[edge] = f - rf
+				delete(flow.Flows, edge.Rev())
+			} else if f == rf {
+				delete(flow.Flows, edge)
+				delete(flow.Flows, edge.Rev())
+			}
+		}
+	}
+
+	side := fn.sourceSide(in(idx[s]))
+	for arc, edge := range edgeArcs {
+		if side[fn.to[arc ^ 1]] && !side[fn.to[arc]] {
+			flow.Cut = append(flow.Cut, edge)
+		}
+	}
+	for arc, n := range nodeArcs {
+		if side[fn.to[arc ^ 1]] && !side[fn.to[arc]] {
+			flow.CutNodes = append(flow.CutNodes, n)
+		}
+	}
+	return flow, nil
+}
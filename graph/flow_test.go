@@ -0,0 +1,161 @@
+package graph_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/dusktreader/advent-of-code-2024/graph"
+	"github.com/dusktreader/advent-of-code-2024/util"
+)
+
+func TestMaxFlow(t *testing.T) {
+	g := graph.MakeDigraph[string]()
+	g.AddEdge("s", "a", 16)
+	g.AddEdge("s", "c", 13)
+	g.AddEdge("a", "b", 12)
+	g.AddEdge("c", "a", 4)
+	g.AddEdge("b", "c", 9)
+	g.AddEdge("c", "d", 14)
+	g.AddEdge("d", "b", 7)
+	g.AddEdge("b", "t", 20)
+	g.AddEdge("d", "t", 4)
+
+	flow, err := g.MaxFlow(context.Background(), "s", "t")
+	util.Unexpect(t, err)
+	if flow.Value != 23 {
+		t.Errorf("Wrong max flow: want 23, got %v", flow.Value)
+	}
+
+	cut := 0
+	for _, edge := range flow.Cut {
+		cut += edge.Wt
+	}
+	if cut != flow.Value {
+		t.Errorf("Min cut %v should weigh the same as the max flow %v", flow.Cut, flow.Value)
+	}
+
+	// Flow is conserved everywhere but the source and sink, and never goes over capacity
+	net := make(map[string]int)
+	for edge, f := range flow.Flows {
+		if f > edge.Wt {
+			t.Errorf("Edge %v carries %v, more than its capacity", edge, f)
+		}
+		net[edge.From] -= f
+		net[edge.To] += f
+	}
+	for n, f := range net {
+		if n != "s" && n != "t" && f != 0 {
+			t.Errorf("Flow isn't conserved at %v: %v", n, f)
+		}
+	}
+	if net["t"] != 23 {
+		t.Errorf("Wrong flow into the sink: want 23, got %v", net["t"])
+	}
+}
+
+func TestMaxFlowUndirected(t *testing.T) {
+	g := graph.MakeGraph[int](false)
+	g.AddEdge(1, 2, 3)
+	g.AddEdge(3, 1, 2)
+	g.AddEdge(2, 3, 1)
+	g.AddEdge(4, 2, 2)
+	g.AddEdge(3, 4, 4)
+
+	flow, err := g.MaxFlow(context.Background(), 1, 4)
+	util.Unexpect(t, err)
+	if flow.Value != 5 {
+		t.Errorf("Wrong max flow: want 5, got %v", flow.Value)
+	}
+	for edge := range flow.Flows {
+		if _, ok := flow.Flows[edge.Rev()]; ok {
+			t.Errorf("Flow should only go one way along %v: %v", edge, flow.Flows)
+		}
+	}
+
+	_, err = g.MaxFlow(context.Background(), 1, 1)
+	if err == nil {
+		t.Errorf("Expected an error when the source is the sink")
+	}
+}
+
+func TestMaxFlowNodes(t *testing.T) {
+	g := gridGraph(
+		".....",
+		".#.#.",
+		".....",
+		"##.##",
+		".....",
+	)
+	start := util.MakePoint(0, 0)
+	end := util.MakePoint(4, 4)
+	one := func(util.Point) int { return 1 }
+
+	flow, err := g.MaxFlowNodes(context.Background(), start, end, one)
+	util.Unexpect(t, err)
+	if flow.Value != 1 {
+		t.Errorf("Wrong node cut size: want 1, got %v", flow.Value)
+	}
+	if len(flow.CutNodes) != 1 {
+		t.Fatalf("Expected one cut node, got %v", flow.CutNodes)
+	}
+	cut := g.Clone()
+	cut.Rem(flow.CutNodes[0])
+	_, _, ok, err := cut.BFSFrom(context.Background(), []util.Point{start}, func(pt util.Point) bool { return pt == end })
+	util.Unexpect(t, err)
+	if ok {
+		t.Errorf("Removing %v should cut off the end", flow.CutNodes[0])
+	}
+}
+
+func TestMaxFlowNodesNextToSource(t *testing.T) {
+	// The only way on from s is through a, so a has to be the cut even though the edge into it is just as cheap
+	g := graph.MakeGraph[string](false)
+	g.AddEdge("s", "a")
+	g.AddEdge("a", "b")
+	g.AddEdge("a", "c")
+	g.AddEdge("b", "t")
+	g.AddEdge("c", "t")
+	one := func(string) int { return 1 }
+
+	flow, err := g.MaxFlowNodes(context.Background(), "s", "t", one)
+	util.Unexpect(t, err)
+	if flow.Value != 1 {
+		t.Errorf("Wrong node cut size: want 1, got %v", flow.Value)
+	}
+	if len(flow.CutNodes) != 1 || flow.CutNodes[0] != "a" {
+		t.Errorf("Wrong cut nodes: want [a], got %v", flow.CutNodes)
+	}
+	if len(flow.Cut) != 0 {
+		t.Errorf("Only nodes should be cut, got edges %v", flow.Cut)
+	}
+
+	g.AddEdge("s", "t")
+	_, err = g.MaxFlowNodes(context.Background(), "s", "t", one)
+	if err == nil {
+		t.Errorf("Expected an error when s and t are neighbors")
+	}
+}
+
+func TestMaxFlowLarge(t *testing.T) {
+	size := 200
+	rows := make([]string, size)
+	for i := range rows {
+		rows[i] = strings.Repeat(".", size)
+	}
+	g := gridGraph(rows...)
+	start := util.MakePoint(0, 0)
+	end := util.MakePoint(size - 1, size - 1)
+
+	flow, err := g.MaxFlowNodes(context.Background(), start, end, func(util.Point) int { return 1 })
+	util.Unexpect(t, err)
+	if flow.Value != 2 {
+		t.Errorf("Wrong node cut size for a corner: want 2, got %v", flow.Value)
+	}
+
+	flow, err = g.MaxFlow(context.Background(), util.MakePoint(size / 2, size / 2), end)
+	util.Unexpect(t, err)
+	if flow.Value != 2 {
+		t.Errorf("Wrong edge cut size for a corner: want 2, got %v", flow.Value)
+	}
+}
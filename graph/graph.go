@@ -1,13 +1,11 @@
 package graph
 
 import (
+	"context"
 	"fmt"
-	"log/slog"
-	"math"
-	"net"
+	"iter"
 	"strings"
 
-	"github.com/dusktreader/advent-of-code-2024/heap"
 	"github.com/dusktreader/advent-of-code-2024/util"
 )
 
@@ -27,6 +25,8 @@ func (e Edge[T]) String() string {
 	return fmt.Sprintf("%v -> %v", e.From, e.To)
 }
 
+var logger = util.Logger("graph")
+
 type Graph [T comparable] struct {
 	directed    bool
 	nodes       util.Set[T]
@@ -310,42 +310,6 @@ func (g *Graph[T]) Nbors(n T) util.Set[T] {
 	return nodes
 }
 
-func (g *Graph[T]) CnxComp() []Graph[T] {
-	if g.directed {
-		slog.Error("Connected components not implemented yet for digraphs")
-		return nil
-	}
-
-	nodes := g.Nodes()
-	comp := make([]Graph[T], 0, nodes.Size() / 2)
-	cnx := MakeGraph[T](g.directed)
-
-	visited := util.MakeSet[T]()
-
-	var dfs func(T)
-	dfs = func(n T) {
-		visited.Add(n)
-		for e := range g.Nbors(n).Iter() {
-			cnx.AddEdge(n, e)
-			nodes.Rem(e)
-			if !visited.Has(e) {
-				dfs(e)
-			}
-		}
-		visited.Rem(n)
-	}
-
-	for !nodes.Empty() {
-		cnx.Clear()
-		n := nodes.Pop()
-		cnx.Add(n)
-		dfs(n)
-		comp = append(comp, *cnx.Clone())
-	}
-
-	return comp
-}
-
 func (g *Graph[T]) Terminals(withSource bool) util.Set[T] {
 	if g.directed == false {
 		return util.MakeSet[T]()
@@ -427,37 +391,44 @@ func (g *Graph[T]) HasCycle() bool {
 	return false
 }
 
-func (g *Graph[T]) HasPath(a T, b T) bool {
+func (g *Graph[T]) HasPath(ctx context.Context, a T, b T) (bool, error) {
 	visited := util.MakeSet[T]()
 
-	var dfs func(T, T) bool
-	dfs = func(a T, b T) bool {
+	var dfs func(T, T) (bool, error)
+	dfs = func(a T, b T) (bool, error) {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
 		visited.Add(a)
 		if a == b {
-			return true
+			return true, nil
 		} else {
 			for e := range g.OutN(a).Iter() {
 				if !visited.Has(e) {
-					if dfs(e, b) {
-						return true
+					found, err := dfs(e, b)
+					if found || err != nil {
+						return found, err
 					}
 				}
 			}
 		}
 		visited.Rem(a)
-		return false
+		return false, nil
 	}
 	return dfs(a, b)
 }
 
-func (g *Graph[T]) Paths(a T, b T) [][]T{
+func (g *Graph[T]) Paths(ctx context.Context, a T, b T) ([][]T, error) {
 
 	paths := make([][]T, 0, g.nodes.Size())
 	stack  := util.MakeStack[T]()
 	visited := util.MakeSet[T]()
 
-	var dfs func(T, T)
-	dfs = func(a T, b T) {
+	var dfs func(T, T) error
+	dfs = func(a T, b T) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		visited.Add(a)
 		stack.Push(a)
 		if a == b {
@@ -465,15 +436,21 @@ func (g *Graph[T]) Paths(a T, b T) [][]T{
 		} else {
 			for e := range g.OutN(a).Iter() {
 				if !visited.Has(e) {
-					dfs(e, b)
+					if err := dfs(e, b); err != nil {
+						return err
+					}
 				}
 			}
 		}
 		stack.Pop()
 		visited.Rem(a)
+		return nil
+	}
+	if err := dfs(a, b); err != nil {
+		return nil, err
 	}
-	dfs(a, b)
-	return paths
+	logger.Debug("Found paths", "from", a, "to", b, "count", len(paths))
+	return paths, nil
 }
 
 func (g *Graph[T]) GetTopo() ([]T, error) {
@@ -482,14 +459,13 @@ func (g *Graph[T]) GetTopo() ([]T, error) {
 	}
 
 	if g.sortedNodes == nil {
-		g.sortedNodes = make([]T, g.nodes.Size())
-		i := 0
+		// Only cache a finished sort, or a cyclic graph would hand back a partial one next time
+		sorted := make([]T, 0, g.nodes.Size())
 		tempDigraph := g.Clone()
 		sources := tempDigraph.Sources()
 		for !sources.Empty() {
 			n := sources.Pop()
-			g.sortedNodes[i] = n
-			i++
+			sorted = append(sorted, n)
 			for m := range tempDigraph.OutN(n).Iter() {
 				tempDigraph.RemEdge(n, m)
 				if tempDigraph.InN(m).Size() == 0 {
@@ -500,6 +476,7 @@ func (g *Graph[T]) GetTopo() ([]T, error) {
 		if tempDigraph.Edges().Size() > 0 {
 			return nil, fmt.Errorf("Digraph has a cycle and cannot be topologically sorted")
 		}
+		g.sortedNodes = sorted
 	}
 	return g.sortedNodes, nil
 }
@@ -543,45 +520,83 @@ func (g *Graph[T]) Sort(items []T) ([]T, error) {
 	return nil, fmt.Errorf("Couldn't sort items %+v using digraph", items)
 }
 
-func (g *Graph[T]) ShortestPaths(a T, b T) ([][]T, error) {
-	pq := heap.MakeMinHeap[T]()
-	pg := MakeDigraph[T]()
-
-	for n := range g.nodes.Iter() {
-		pq.Insert(math.MaxInt, n)
+// Edges leaving n. Undirected edges are flipped as needed so From is always n
+func (g *Graph[T]) adj(n T) iter.Seq[Edge[T]] {
+	return func(yield func(Edge[T]) bool) {
+		for edge := range g.nodeEdgeMap.Get(n).Iter() {
+			if edge.From != n {
+				if g.directed {
+					continue
+				}
+				edge = edge.Rev()
+			}
+			if !yield(edge) {
+				return
+			}
+		}
 	}
-	pq.ChangeWeight(0, a)
+}
 
-	for !pq.Empty() {
-		weight, node, err := pq.Extract()
-		if err != nil {
-			return nil, util.ReErr(err, "Couldn't extract from heap!")
-		}
-		for nbor := range g.Nbors(node).Iter() {
-			if !pq.Has(nbor) {
-				continue
+func (g *Graph[T]) Neighbors(n T) iter.Seq2[T, int] {
+	return func(yield func(T, int) bool) {
+		for edge := range g.adj(n) {
+			if !yield(edge.To, edge.Wt) {
+				return
 			}
-			newWeight := weight + g.Edge(node, nbor).Wt
-			if newWeight < pq.Weight(nbor) {
-				pq.ChangeWeight(newWeight, nbor)
-
-				if pg.Has(nbor) {
-					prevs := pg.OutN(nbor)
-					if prevs.Size() > 0 {
-						first := prevs.First()
-						edge := pg.Edge(nbor, first)
-						if edge.Wt > newWeight {
-							pg.RemEdge(nbor, first)
-							pg.AddEdge(node, nbor, newWeight)
-						}
-						pw := pg.edge
-					}
-				}
+		}
+	}
+}
 
-				if pg.OutN(nbor)
-				pg.AddEdge(nbor, node)
-			}
-		}}
+// Runs Dijkstra from a over non-negative weights. Returns the distance to every reachable node and a
+// digraph holding every edge that lies on some shortest path from a, so tied paths are all kept
+func (g *Graph[T]) Dijkstra(ctx context.Context, a T) (map[T]int, *Graph[T], error) {
+	if !g.Has(a) {
+		return nil, nil, fmt.Errorf("Start node %v is not in the graph", a)
+	}
+	return Implicit[T](g.Neighbors).Dijkstra(ctx, a)
+}
 
+// Finds the minimal weight from a to b and the digraph of every edge on a path that achieves it
+func (g *Graph[T]) ShortestPathDAG(ctx context.Context, a T, b T) (int, *Graph[T], error) {
+	if !g.Has(a) {
+		return 0, nil, fmt.Errorf("Start node %v is not in the graph", a)
+	}
+	weight, best, err := Implicit[T](g.Neighbors).ShortestPathDAG(ctx, a, func(n T) bool { return n == b })
+	if ctx.Err() != nil {
+		return 0, nil, err
+	} else if err != nil {
+		return 0, nil, fmt.Errorf("No path from %v to %v: %w", a, b, err)
+	}
+	return weight, best, nil
+}
+
+// Finds the minimal weight from a to b and every path that achieves it
+func (g *Graph[T]) ShortestPaths(ctx context.Context, a T, b T) (int, [][]T, error) {
+	weight, best, err := g.ShortestPathDAG(ctx, a, b)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	paths := make([][]T, 0)
+	stack := util.MakeStack[T]()
+
+	var walk func(T)
+	walk = func(n T) {
+		stack.Push(n)
+		if n == a {
+			path := make([]T, 0, stack.Size())
+			items := *stack.Slice()
+			for i := len(items) - 1; i >= 0; i-- {
+				path = append(path, items[i])
+			}
+			paths = append(paths, path)
+		} else {
+			for prev := range best.InN(n).Iter() {
+				walk(prev)
+			}
+		}
+		stack.Pop()
 	}
+	walk(b)
+	return weight, paths, nil
 }
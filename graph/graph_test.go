@@ -1,6 +1,7 @@
 package graph_test
 
 import (
+	"context"
 	"log/slog"
 	"reflect"
 	"testing"
@@ -340,7 +341,8 @@ func TestCnxComp(t *testing.T) {
 	)
 	want[2].Add(3)
 
-	got := g.CnxComp()
+	got, err := g.CnxComp(context.Background())
+	util.Unexpect(t, err)
 	if len(got) != len(want) {
 		t.Fatalf("Got different item count")
 	}
@@ -389,9 +391,85 @@ func TestPathsDirected(t *testing.T) {
 		{1, 2, 3},
 		{1, 5, 2, 3},
 	}
-	got := g.Paths(1, 3)
+	got, err := g.Paths(context.Background(), 1, 3)
+	util.Unexpect(t, err)
 
 	if !pathsEq(&want, &got) {
 		t.Errorf("Failed: want %+v, got %+v", want, got)
 	}
 }
+
+func TestShortestPathsDirected(t *testing.T) {
+	g := graph.MakeDigraph[int]()
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(1, 3, 3)
+	g.AddEdge(2, 3, 2)
+	g.AddEdge(2, 4, 5)
+	g.AddEdge(3, 4, 1)
+	g.AddEdge(1, 4, 9)
+	g.AddEdge(4, 5, 3)
+
+	wantWeight := 4
+	want := [][]int{
+		{1, 3, 4},
+		{1, 2, 3, 4},
+	}
+	gotWeight, got, err := g.ShortestPaths(context.Background(), 1, 4)
+	util.Unexpect(t, err)
+	if gotWeight != wantWeight {
+		t.Errorf("Wrong weight: want %v, got %v", wantWeight, gotWeight)
+	}
+	if len(got) != len(want) || !pathsEq(&want, &got) {
+		t.Errorf("Failed: want %+v, got %+v", want, got)
+	}
+
+	_, _, err = g.ShortestPaths(context.Background(), 4, 1)
+	if err == nil {
+		t.Errorf("Expected an error for an unreachable node")
+	}
+}
+
+func TestShortestPathsUndirected(t *testing.T) {
+	g := graph.MakeGraph[string](false)
+	g.AddEdge("a", "b", 2)
+	g.AddEdge("c", "a", 1)
+	g.AddEdge("c", "b", 1)
+	g.AddEdge("d", "b", 1)
+	g.AddEdge("c", "d", 3)
+
+	wantWeight := 3
+	want := [][]string{
+		{"a", "b", "d"},
+		{"a", "c", "b", "d"},
+	}
+	gotWeight, got, err := g.ShortestPaths(context.Background(), "a", "d")
+	util.Unexpect(t, err)
+	if gotWeight != wantWeight {
+		t.Errorf("Wrong weight: want %v, got %v", wantWeight, gotWeight)
+	}
+	if len(got) != len(want) || !pathsEq(&want, &got) {
+		t.Errorf("Failed: want %+v, got %+v", want, got)
+	}
+
+	dist, _, err := g.Dijkstra(context.Background(), "d")
+	util.Unexpect(t, err)
+	wantDist := map[string]int{"a": 3, "b": 1, "c": 2, "d": 0}
+	if !reflect.DeepEqual(dist, wantDist) {
+		t.Errorf("Wrong distances: want %v, got %v", wantDist, dist)
+	}
+}
+
+func TestDijkstraFail(t *testing.T) {
+	g := graph.MakeDigraph[int]()
+	g.AddEdge(1, 2, -1)
+
+	_, _, err := g.Dijkstra(context.Background(), 1)
+	if err == nil {
+		t.Errorf("Expected an error for a negative weight")
+	}
+
+	_, _, err = g.Dijkstra(context.Background(), 3)
+	if err == nil {
+		t.Errorf("Expected an error for a missing start node")
+	}
+}
@@ -0,0 +1,61 @@
+package graph
+
+import (
+	"fmt"
+
+	"github.com/dusktreader/advent-of-code-2024/util"
+)
+
+// Which cells around a grid cell count as its neighbors
+type Neighborhood int
+
+const (
+	NEIGHBORS4 Neighborhood = 4
+	NEIGHBORS8 Neighborhood = 8
+)
+
+// The first half of each neighborhood only looks ahead in reading order, so each pair of cells comes up once
+var hoodOffsets = map[Neighborhood][]util.Vector{
+	NEIGHBORS4: {
+		util.EAST, util.SOUTH,
+		util.WEST, util.NORTH,
+	},
+	NEIGHBORS8: {
+		util.EAST, util.SOUTH, util.MakeVector(1, 1), util.MakeVector(1, -1),
+		util.WEST, util.NORTH, util.MakeVector(-1, -1), util.MakeVector(-1, 1),
+	},
+}
+
+// Builds a graph of grid points. Rule decides whether there is an edge from one cell to a neighboring cell and
+// how much it weighs. For an undirected graph rule is only asked about each pair once, with from coming first
+// in reading order. Cells only become nodes if they have an edge, so add any others that are needed
+func FromGrid[T any](
+	grid *util.Grid[T],
+	hood Neighborhood,
+	directed bool,
+	rule func(from T, to T) (weight int, ok bool),
+) (*Graph[util.Point], error) {
+	offsets, ok := hoodOffsets[hood]
+	if !ok {
+		return nil, fmt.Errorf("Unknown neighborhood %v, expected 4 or 8", hood)
+	}
+	if !directed {
+		offsets = offsets[:len(offsets) / 2]
+	}
+
+	g := MakeGraph[util.Point](directed)
+	for from := range grid.Size().Iter() {
+		fromVal, _ := grid.Get(from)
+		for _, off := range offsets {
+			to := from.Add(off)
+			toVal, err := grid.Get(to)
+			if err != nil {
+				continue
+			}
+			if weight, ok := rule(*fromVal, *toVal); ok {
+				g.AddEdge(from, to, weight)
+			}
+		}
+	}
+	return g, nil
+}
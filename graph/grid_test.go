@@ -0,0 +1,58 @@
+package graph_test
+
+import (
+	"testing"
+
+	"github.com/dusktreader/advent-of-code-2024/graph"
+	"github.com/dusktreader/advent-of-code-2024/util"
+)
+
+func TestFromGrid(t *testing.T) {
+	size := util.Size{W: 3, H: 2}
+	grid, err := util.MakeGrid(size, []int{
+		1, 2, 0,
+		2, 3, 4,
+	})
+	util.Unexpect(t, err)
+
+	up := func(from int, to int) (int, bool) {
+		return to - from, from != 0 && to > from
+	}
+
+	g, err := graph.FromGrid(grid, graph.NEIGHBORS4, true, up)
+	util.Unexpect(t, err)
+	want := graph.MakeDigraph[util.Point]()
+	want.AddEdge(util.MakePoint(0, 0), util.MakePoint(0, 1), 1)
+	want.AddEdge(util.MakePoint(0, 0), util.MakePoint(1, 0), 1)
+	want.AddEdge(util.MakePoint(0, 1), util.MakePoint(1, 1), 1)
+	want.AddEdge(util.MakePoint(1, 0), util.MakePoint(1, 1), 1)
+	want.AddEdge(util.MakePoint(1, 1), util.MakePoint(1, 2), 1)
+	if !want.Eq(g) {
+		t.Errorf("Wrong 4-neighbor digraph: want %v, got %v", want, g)
+	}
+
+	g, err = graph.FromGrid(grid, graph.NEIGHBORS8, true, up)
+	util.Unexpect(t, err)
+	want.AddEdge(util.MakePoint(0, 0), util.MakePoint(1, 1), 2)
+	want.AddEdge(util.MakePoint(0, 1), util.MakePoint(1, 2), 2)
+	if !want.Eq(g) {
+		t.Errorf("Wrong 8-neighbor digraph: want %v, got %v", want, g)
+	}
+
+	open := func(from int, to int) (int, bool) {
+		return 1, from != 0 && to != 0
+	}
+	g, err = graph.FromGrid(grid, graph.NEIGHBORS4, false, open)
+	util.Unexpect(t, err)
+	if g.Has(util.MakePoint(0, 2)) || g.Edges().Size() != 5 {
+		t.Errorf("Wrong undirected graph: %v", g)
+	}
+	if _, ok := g.Edge(util.MakePoint(1, 1), util.MakePoint(0, 1)); !ok {
+		t.Errorf("Undirected edges should go both ways: %v", g)
+	}
+
+	_, err = graph.FromGrid(grid, graph.Neighborhood(6), false, open)
+	if err == nil {
+		t.Errorf("Expected an error for an unknown neighborhood")
+	}
+}
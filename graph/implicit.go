@@ -0,0 +1,180 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"iter"
+
+	"github.com/dusktreader/advent-of-code-2024/heap"
+	"github.com/dusktreader/advent-of-code-2024/util"
+)
+
+// A graph that is never built. Its nodes are found by asking for the neighbors of a state along with the
+// weight of the edge to each, so searches only touch the states they reach. *Graph[T].Neighbors fits
+type Implicit[T comparable] func(state T) iter.Seq2[T, int]
+
+// Finds a path from start to the nearest goal by edge count, ignoring weights
+func (im Implicit[T]) BFS(ctx context.Context, start T, isGoal func(T) bool) ([]T, error) {
+	tree, goal, ok, err := im.BFSFrom(ctx, []T{start}, isGoal)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, fmt.Errorf("No path from %v to a goal", start)
+	}
+	path, _ := tree.PathTo(goal)
+	return path, nil
+}
+
+// Runs Dijkstra from start over non-negative weights. Returns the distance to every reachable state and a
+// digraph holding every edge that lies on some shortest path from start, so tied paths are all kept. Only
+// ends if the reachable states are finite
+func (im Implicit[T]) Dijkstra(ctx context.Context, start T) (map[T]int, *Graph[T], error) {
+	dist, preds, _, err := im.dijkstra(ctx, start, nil)
+	return dist, preds, err
+}
+
+// The search stops once every goal as cheap as the first one found has been reached. Goals aren't expanded
+func (im Implicit[T]) dijkstra(
+	ctx context.Context,
+	start T,
+	isGoal func(T) bool,
+) (dist map[T]int, preds *Graph[T], goals []T, err error) {
+	dist = map[T]int{start: 0}
+	preds = MakeDigraph[T]()
+	preds.Add(start)
+	done := util.MakeSet[T]()
+
+	pq := heap.MakeMinHeap[T]()
+	pq.Insert(0, start)
+
+	for !pq.Empty() {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, nil, err
+		}
+
+		weight, node, err := pq.Extract()
+		if err != nil {
+			return nil, nil, nil, util.ReErr(err, "Couldn't extract from heap!")
+		}
+		if len(goals) > 0 && weight > dist[goals[0]] {
+			break
+		}
+		done.Add(node)
+		if isGoal != nil && isGoal(node) {
+			goals = append(goals, node)
+			continue
+		}
+
+		for nbor, wt := range im(node) {
+			if wt < 0 {
+				return nil, nil, nil, fmt.Errorf("Dijkstra can't handle negative weight %v from %v to %v", wt, node, nbor)
+			}
+
+			newWeight := weight + wt
+			oldWeight, seen := dist[nbor]
+			switch {
+			case !seen:
+				dist[nbor] = newWeight
+				pq.Insert(newWeight, nbor)
+				preds.AddEdge(node, nbor, wt)
+			case newWeight < oldWeight:
+				dist[nbor] = newWeight
+				err = pq.ChangeWeight(newWeight, nbor)
+				if err != nil {
+					return nil, nil, nil, util.ReErr(err, "Couldn't lower weight of %v", nbor)
+				}
+				for prev := range preds.InN(nbor).Iter() {
+					preds.RemEdge(prev, nbor)
+				}
+				preds.AddEdge(node, nbor, wt)
+			case newWeight == oldWeight && !done.Has(nbor):
+				// Only linking to unsettled states keeps preds acyclic even with zero weights
+				preds.AddEdge(node, nbor, wt)
+			}
+		}
+	}
+	return dist, preds, goals, nil
+}
+
+// Finds the minimal weight from start to any goal and the digraph of every edge on a path that achieves it
+func (im Implicit[T]) ShortestPathDAG(ctx context.Context, start T, isGoal func(T) bool) (int, *Graph[T], error) {
+	dist, preds, goals, err := im.dijkstra(ctx, start, isGoal)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(goals) == 0 {
+		return 0, nil, fmt.Errorf("No path from %v to a goal", start)
+	}
+
+	best := MakeDigraph[T]()
+	stack := util.MakeStack[T]()
+	for _, goal := range goals {
+		best.Add(goal)
+		stack.Push(goal)
+	}
+	for stack.Size() > 0 {
+		node, _ := stack.Pop()
+		for prev := range preds.InN(node).Iter() {
+			if !best.Has(prev) {
+				stack.Push(prev)
+			}
+			edge, _ := preds.Edge(prev, node)
+			best.AddEdge(prev, node, edge.Wt)
+		}
+	}
+	return dist[goals[0]], best, nil
+}
+
+// Finds a cheapest path from start to a goal, searching the states the heuristic says are closest to a goal
+// first. Also reports how many states were expanded so heuristics can be compared
+func (im Implicit[T]) AStar(
+	ctx context.Context,
+	start T,
+	isGoal func(T) bool,
+	h func(T) int,
+) (path []T, cost int, expanded int, err error) {
+	dist := map[T]int{start: 0}
+	parents := make(map[T]T)
+
+	open := heap.MakeMinHeap[T]()
+	open.Insert(h(start), start)
+
+	for !open.Empty() {
+		if err := ctx.Err(); err != nil {
+			return nil, 0, expanded, err
+		}
+
+		_, node, err := open.Extract()
+		if err != nil {
+			return nil, 0, expanded, util.ReErr(err, "Couldn't extract from heap!")
+		}
+		if isGoal(node) {
+			return walkBack(parents, start, node), dist[node], expanded, nil
+		}
+		expanded++
+
+		for nbor, wt := range im(node) {
+			if wt < 0 {
+				return nil, 0, expanded, fmt.Errorf("A* can't handle negative weight %v from %v to %v", wt, node, nbor)
+			}
+
+			newDist := dist[node] + wt
+			if oldDist, seen := dist[nbor]; seen && newDist >= oldDist {
+				continue
+			}
+			dist[nbor] = newDist
+			parents[nbor] = node
+
+			// An inconsistent heuristic can find a cheaper way into an expanded state, so it goes back in the heap
+			if open.Has(nbor) {
+				err = open.ChangeWeight(newDist + h(nbor), nbor)
+				if err != nil {
+					return nil, 0, expanded, util.ReErr(err, "Couldn't lower weight of %v", nbor)
+				}
+			} else {
+				open.Insert(newDist + h(nbor), nbor)
+			}
+		}
+	}
+	return nil, 0, expanded, fmt.Errorf("No path from %v to a goal", start)
+}
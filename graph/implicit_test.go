@@ -0,0 +1,115 @@
+package graph_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"testing"
+	"time"
+
+	"github.com/dusktreader/advent-of-code-2024/graph"
+	"github.com/dusktreader/advent-of-code-2024/util"
+)
+
+// An endless grid where stepping east costs 1 and every other direction costs 2
+func lattice(pt util.Point) iter.Seq2[util.Point, int] {
+	return func(yield func(util.Point, int) bool) {
+		for _, dir := range []util.Vector{util.NORTH, util.EAST, util.SOUTH, util.WEST} {
+			wt := 2
+			if dir == util.EAST {
+				wt = 1
+			}
+			if !yield(pt.Add(dir), wt) {
+				return
+			}
+		}
+	}
+}
+
+func TestImplicitBFS(t *testing.T) {
+	im := graph.Implicit[util.Point](lattice)
+	start := util.MakePoint(0, 0)
+	goal := util.MakePoint(2, -3)
+
+	path, err := im.BFS(context.Background(), start, func(pt util.Point) bool { return pt == goal })
+	util.Unexpect(t, err)
+	if len(path) != 6 || path[0] != start || path[5] != goal {
+		t.Errorf("Expected a 5 step path from %v to %v, got %v", start, goal, path)
+	}
+}
+
+func TestImplicitShortestPathDAG(t *testing.T) {
+	im := graph.Implicit[util.Point](lattice)
+	start := util.MakePoint(0, 0)
+	isGoal := func(pt util.Point) bool { return pt.I == 1 && pt.J >= 1 }
+
+	weight, best, err := im.ShortestPathDAG(context.Background(), start, isGoal)
+	util.Unexpect(t, err)
+
+	wantWeight := 3
+	if weight != wantWeight {
+		t.Errorf("Wrong weight: want %v, got %v", wantWeight, weight)
+	}
+
+	want := util.MakeSet(start, util.MakePoint(1, 0), util.MakePoint(0, 1), util.MakePoint(1, 1))
+	got := best.Nodes()
+	if !want.Eq(got) {
+		t.Errorf("Wrong nodes on best paths: want %v, got %v", want, got)
+	}
+}
+
+func TestImplicitAStar(t *testing.T) {
+	im := graph.Implicit[util.Point](lattice)
+	start := util.MakePoint(0, 0)
+	goal := util.MakePoint(-3, 4)
+	isGoal := func(pt util.Point) bool { return pt == goal }
+	h := func(pt util.Point) int { return graph.Manhattan(pt, goal) }
+
+	path, cost, _, err := im.AStar(context.Background(), start, isGoal, h)
+	util.Unexpect(t, err)
+
+	wantCost := 10
+	if cost != wantCost {
+		t.Errorf("Wrong cost: want %v, got %v", wantCost, cost)
+	}
+	if len(path) != 8 || path[0] != start || path[7] != goal {
+		t.Errorf("Expected a 7 step path from %v to %v, got %v", start, goal, path)
+	}
+}
+
+func TestImplicitNegativeWeight(t *testing.T) {
+	im := graph.Implicit[int](func(n int) iter.Seq2[int, int] {
+		return func(yield func(int, int) bool) {
+			if n < 3 {
+				yield(n + 1, -1)
+			}
+		}
+	})
+
+	_, _, err := im.Dijkstra(context.Background(), 0)
+	if err == nil {
+		t.Errorf("Expected an error for a negative weight")
+	}
+}
+
+func TestImplicitCancel(t *testing.T) {
+	im := graph.Implicit[util.Point](lattice)
+	start := util.MakePoint(0, 0)
+	never := func(util.Point) bool { return false }
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10 * time.Millisecond)
+	defer cancel()
+
+	_, err := im.BFS(ctx, start, never)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected BFS to time out, got %v", err)
+	}
+	_, _, err = im.ShortestPathDAG(ctx, start, never)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected Dijkstra to time out, got %v", err)
+	}
+	_, _, _, err = im.AStar(ctx, start, never, func(util.Point) int { return 0 })
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected A* to time out, got %v", err)
+	}
+}
@@ -0,0 +1,85 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/dusktreader/advent-of-code-2024/heap"
+	"github.com/dusktreader/advent-of-code-2024/util"
+)
+
+// Builds a minimum spanning tree by taking the lightest edges first and skipping any that would close a loop.
+// A disconnected graph gets a spanning forest. Returns the tree and its total weight
+func (g *Graph[T]) Kruskal() (*Graph[T], int, error) {
+	if g.directed {
+		return nil, 0, fmt.Errorf("Spanning trees need a non-directed graph")
+	}
+
+	edges := g.edges.Items()
+	sort.SliceStable(edges, func(i, j int) bool { return edges[i].Wt < edges[j].Wt })
+
+	tree := MakeGraph[T](false)
+	uf := util.MakeUnionFind(g.nodes.Items()...)
+	weight := 0
+	for n := range g.nodes.Iter() {
+		tree.Add(n)
+	}
+	for _, edge := range edges {
+		if uf.Union(edge.From, edge.To) {
+			tree.AddEdge(edge.From, edge.To, edge.Wt)
+			weight += edge.Wt
+		}
+	}
+	return tree, weight, nil
+}
+
+// Builds a minimum spanning tree by growing it from one node, always adding the lightest edge that reaches a
+// new node. A disconnected graph gets a spanning forest. Returns the tree and its total weight
+func (g *Graph[T]) Prim() (*Graph[T], int, error) {
+	if g.directed {
+		return nil, 0, fmt.Errorf("Spanning trees need a non-directed graph")
+	}
+
+	tree := MakeGraph[T](false)
+	weight := 0
+	best := make(map[T]Edge[T])
+
+	for root := range g.nodes.Iter() {
+		if tree.Has(root) {
+			continue
+		}
+
+		pq := heap.MakeMinHeap[T]()
+		pq.Insert(0, root)
+		for !pq.Empty() {
+			_, node, err := pq.Extract()
+			if err != nil {
+				return nil, 0, util.ReErr(err, "Couldn't extract from heap!")
+			}
+
+			tree.Add(node)
+			if edge, ok := best[node]; ok {
+				tree.AddEdge(edge.From, edge.To, edge.Wt)
+				weight += edge.Wt
+			}
+
+			for edge := range g.adj(node) {
+				nbor := edge.To
+				if tree.Has(nbor) {
+					continue
+				}
+				if old, ok := best[nbor]; !ok {
+					best[nbor] = edge
+					pq.Insert(edge.Wt, nbor)
+				} else if edge.Wt < old.Wt {
+					best[nbor] = edge
+					err = pq.ChangeWeight(edge.Wt, nbor)
+					if err != nil {
+						return nil, 0, util.ReErr(err, "Couldn't lower weight of %v", nbor)
+					}
+				}
+			}
+		}
+	}
+	return tree, weight, nil
+}
@@ -0,0 +1,58 @@
+package graph_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dusktreader/advent-of-code-2024/graph"
+	"github.com/dusktreader/advent-of-code-2024/util"
+)
+
+func TestMST(t *testing.T) {
+	g := graph.MakeGraph[string](false)
+	g.AddEdge("a", "b", 4)
+	g.AddEdge("a", "h", 8)
+	g.AddEdge("b", "c", 8)
+	g.AddEdge("b", "h", 11)
+	g.AddEdge("c", "d", 7)
+	g.AddEdge("c", "f", 4)
+	g.AddEdge("c", "i", 2)
+	g.AddEdge("d", "e", 9)
+	g.AddEdge("d", "f", 14)
+	g.AddEdge("e", "f", 10)
+	g.AddEdge("f", "g", 2)
+	g.AddEdge("g", "h", 1)
+	g.AddEdge("g", "i", 6)
+	g.AddEdge("h", "i", 7)
+	g.AddEdge("x", "y", 3)
+	g.Add("z")
+
+	for name, mst := range map[string]func() (*graph.Graph[string], int, error){
+		"Kruskal": g.Kruskal,
+		"Prim":    g.Prim,
+	} {
+		tree, weight, err := mst()
+		util.Unexpect(t, err)
+
+		if weight != 40 {
+			t.Errorf("%v found the wrong weight: want 40, got %v", name, weight)
+		}
+		if !tree.Nodes().Eq(g.Nodes()) {
+			t.Errorf("%v should span every node: %v", name, tree)
+		}
+		if tree.Edges().Size() != g.Nodes().Size() - 3 {
+			t.Errorf("%v should have one edge fewer than nodes per component: %v", name, tree)
+		}
+
+		comps, err := tree.CnxComp(context.Background())
+		util.Unexpect(t, err)
+		if len(comps) != 3 {
+			t.Errorf("%v should keep the 3 components apart, got %v", name, len(comps))
+		}
+	}
+
+	_, _, err := graph.MakeDigraph[int]().Kruskal()
+	if err == nil {
+		t.Errorf("Expected an error for a digraph")
+	}
+}
@@ -0,0 +1,197 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/dusktreader/advent-of-code-2024/util"
+)
+
+// The subgraph holding only the given nodes and the edges between them
+func (g *Graph[T]) induced(nodes util.Set[T]) *Graph[T] {
+	sub := MakeGraph[T](g.directed)
+	for n := range nodes.Iter() {
+		sub.Add(n)
+		for edge := range g.nodeEdgeMap.Get(n).Iter() {
+			if edge.From == n && nodes.Has(edge.To) {
+				sub.AddEdge(edge.From, edge.To, edge.Wt)
+			}
+		}
+	}
+	return sub
+}
+
+// Finds strongly connected components with Tarjan's algorithm. Components come out in topological order,
+// so sources are first. For an undirected graph these are just the connected components
+func (g *Graph[T]) SCC(ctx context.Context) ([]util.Set[T], error) {
+	// Each frame stands in for one call of the recursive version, so a long chain grows a slice rather than
+	// the call stack. Next is the neighbor the call picks up at when it's back on top
+	type frame struct {
+		node  T
+		nbors []T
+		next  int
+	}
+
+	index := make(map[T]int)
+	low := make(map[T]int)
+	onStack := util.MakeSet[T]()
+	stack := make([]T, 0)
+	frames := make([]frame, 0)
+	comps := make([]util.Set[T], 0)
+
+	visit := func(n T) {
+		index[n] = len(index)
+		low[n] = index[n]
+		stack = append(stack, n)
+		onStack.Add(n)
+
+		nbors := make([]T, 0)
+		for edge := range g.adj(n) {
+			nbors = append(nbors, edge.To)
+		}
+		frames = append(frames, frame{node: n, nbors: nbors})
+	}
+
+	for root := range g.nodes.Iter() {
+		if _, ok := index[root]; ok {
+			continue
+		}
+		visit(root)
+
+		for len(frames) > 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			top := &frames[len(frames) - 1]
+			node := top.node
+			if top.next < len(top.nbors) {
+				nbor := top.nbors[top.next]
+				top.next++
+				if _, ok := index[nbor]; !ok {
+					visit(nbor)
+				} else if onStack.Has(nbor) {
+					low[node] = min(low[node], index[nbor])
+				}
+				continue
+			}
+
+			frames = frames[:len(frames) - 1]
+			if len(frames) > 0 {
+				parent := frames[len(frames) - 1].node
+				low[parent] = min(low[parent], low[node])
+			}
+
+			if low[node] == index[node] {
+				comp := util.MakeSet[T]()
+				for {
+					member := stack[len(stack) - 1]
+					stack = stack[:len(stack) - 1]
+					onStack.Rem(member)
+					comp.Add(member)
+					if member == node {
+						break
+					}
+				}
+				comps = append(comps, comp)
+			}
+		}
+	}
+
+	// Tarjan finishes sinks first
+	slices.Reverse(comps)
+	logger.Debug("Found strongly connected components", "count", len(comps))
+	return comps, nil
+}
+
+// Finds the components that are connected when edge directions are ignored. Each keeps its original edges
+func (g *Graph[T]) WeakComp(ctx context.Context) ([]Graph[T], error) {
+	uf := util.MakeUnionFind(g.nodes.Items()...)
+	for edge := range g.edges.Iter() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		uf.Union(edge.From, edge.To)
+	}
+
+	sets := uf.Sets()
+	comps := make([]Graph[T], len(sets))
+	for i, nodes := range sets {
+		logger.Debug("Found connected component", "size", nodes.Size())
+		comps[i] = *g.induced(nodes)
+	}
+	return comps, nil
+}
+
+// The connected components of an undirected graph, or the strongly connected components of a digraph
+func (g *Graph[T]) CnxComp(ctx context.Context) ([]Graph[T], error) {
+	if !g.directed {
+		return g.WeakComp(ctx)
+	}
+
+	sccs, err := g.SCC(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	comps := make([]Graph[T], len(sccs))
+	for i, scc := range sccs {
+		comps[i] = *g.induced(scc)
+	}
+	return comps, nil
+}
+
+// A digraph with each strongly connected component squashed into one node. Node i of DAG stands for
+// Comps[i], and the components are numbered in topological order
+type Condensation[T comparable] struct {
+	DAG   *Graph[int]
+	Comps []util.Set[T]
+	Of    map[T]int
+	loops util.Set[int]
+}
+
+// Builds the component DAG, which can always be sorted even when the digraph has cycles
+func (g *Graph[T]) Condense(ctx context.Context) (*Condensation[T], error) {
+	if !g.directed {
+		return nil, fmt.Errorf("Can't condense a non-directed graph")
+	}
+
+	comps, err := g.SCC(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cnd := &Condensation[T]{
+		DAG:   MakeDigraph[int](),
+		Comps: comps,
+		Of:    make(map[T]int),
+		loops: util.MakeSet[int](),
+	}
+	for i, comp := range comps {
+		cnd.DAG.Add(i)
+		for n := range comp.Iter() {
+			cnd.Of[n] = i
+		}
+	}
+	for edge := range g.edges.Iter() {
+		from, to := cnd.Of[edge.From], cnd.Of[edge.To]
+		if from != to {
+			cnd.DAG.AddEdge(from, to)
+		} else if edge.From == edge.To {
+			cnd.loops.Add(from)
+		}
+	}
+	return cnd, nil
+}
+
+// The components with more than one node or a self loop. These are where the digraph's cycles live
+func (c *Condensation[T]) Cyclic() []util.Set[T] {
+	cyclic := make([]util.Set[T], 0)
+	for i, comp := range c.Comps {
+		if comp.Size() > 1 || c.loops.Has(i) {
+			cyclic = append(cyclic, comp)
+		}
+	}
+	return cyclic
+}
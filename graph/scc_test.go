@@ -0,0 +1,142 @@
+package graph_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dusktreader/advent-of-code-2024/graph"
+	"github.com/dusktreader/advent-of-code-2024/util"
+)
+
+func cyclicDigraph() *graph.Graph[int] {
+	g := graph.MakeGraph(
+		true,
+		util.MakePair(1, 2),
+		util.MakePair(2, 3),
+		util.MakePair(3, 1),
+		util.MakePair(3, 4),
+		util.MakePair(4, 5),
+		util.MakePair(5, 4),
+		util.MakePair(6, 5),
+		util.MakePair(7, 7),
+	)
+	g.Add(8)
+	return g
+}
+
+func TestSCC(t *testing.T) {
+	g := cyclicDigraph()
+
+	got, err := g.SCC(context.Background())
+	util.Unexpect(t, err)
+
+	want := []util.Set[int]{
+		util.MakeSet(1, 2, 3),
+		util.MakeSet(4, 5),
+		util.MakeSet(6),
+		util.MakeSet(7),
+		util.MakeSet(8),
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Wrong component count: want %v, got %v", len(want), len(got))
+	}
+
+	pos := make(map[int]int)
+	OUTER: for _, wc := range want {
+		for i, gc := range got {
+			if wc.Eq(gc) {
+				for n := range gc.Iter() {
+					pos[n] = i
+				}
+				continue OUTER
+			}
+		}
+		t.Errorf("Didn't find expected component: %v", wc)
+	}
+
+	if pos[1] > pos[4] || pos[6] > pos[4] {
+		t.Errorf("Components aren't in topological order: %v", got)
+	}
+}
+
+func TestCnxCompDirected(t *testing.T) {
+	g := cyclicDigraph()
+
+	got, err := g.CnxComp(context.Background())
+	util.Unexpect(t, err)
+	if len(got) != 5 {
+		t.Fatalf("Wrong component count: want 5, got %v", len(got))
+	}
+
+	want := graph.MakeGraph(true, util.MakePair(1, 2), util.MakePair(2, 3), util.MakePair(3, 1))
+	found := false
+	for _, comp := range got {
+		found = found || want.Eq(&comp)
+	}
+	if !found {
+		t.Errorf("Didn't find expected subgraph %v in %v", want, got)
+	}
+}
+
+func TestWeakComp(t *testing.T) {
+	g := cyclicDigraph()
+
+	got, err := g.WeakComp(context.Background())
+	util.Unexpect(t, err)
+
+	want := []util.Set[int]{
+		util.MakeSet(1, 2, 3, 4, 5, 6),
+		util.MakeSet(7),
+		util.MakeSet(8),
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Wrong component count: want %v, got %v", len(want), len(got))
+	}
+	OUTER: for _, wc := range want {
+		for _, gc := range got {
+			if wc.Eq(gc.Nodes()) {
+				continue OUTER
+			}
+		}
+		t.Errorf("Didn't find expected component: %v", wc)
+	}
+}
+
+func TestCondense(t *testing.T) {
+	g := cyclicDigraph()
+
+	cnd, err := g.Condense(context.Background())
+	util.Unexpect(t, err)
+
+	if g.HasCycle() == false {
+		t.Fatalf("Expected the original digraph to have a cycle")
+	}
+	if cnd.DAG.HasCycle() {
+		t.Errorf("Condensation should not have a cycle: %v", cnd.DAG)
+	}
+
+	order, err := cnd.DAG.GetTopo()
+	util.Unexpect(t, err)
+	if len(order) != len(cnd.Comps) {
+		t.Errorf("Expected every component in the sort, got %v", order)
+	}
+
+	a, b, c := cnd.Of[1], cnd.Of[4], cnd.Of[6]
+	if cnd.Of[2] != a || cnd.Of[5] != b {
+		t.Errorf("Nodes in a cycle should share a component: %v", cnd.Of)
+	}
+	if !cnd.DAG.OutN(a).Has(b) || !cnd.DAG.OutN(c).Has(b) || cnd.DAG.Edges().Size() != 2 {
+		t.Errorf("Wrong component edges: %v", cnd.DAG)
+	}
+
+	want := 3
+	got := len(cnd.Cyclic())
+	if want != got {
+		t.Errorf("Wrong cyclic component count: want %v, got %v", want, got)
+	}
+
+	_, err = graph.MakeGraph[int](false).Condense(context.Background())
+	if err == nil {
+		t.Errorf("Expected an error condensing an undirected graph")
+	}
+}
@@ -2,9 +2,12 @@ package heap
 
 import (
 	"fmt"
-	"log/slog"
+
+	"github.com/dusktreader/advent-of-code-2024/util"
 )
 
+var logger = util.Logger("heap")
+
 type heapNode [T comparable] struct {
 	weight int
 	value  T
@@ -115,9 +118,9 @@ func (h *Heap[T]) Valid() bool {
 }
 
 func (h *Heap[T]) fix(i int) {
-	slog.Debug("Fixing:", "i", i)
+	logger.Debug("Fixing:", "i", i)
 	for i > 0 && h.cmp(i, h.parent(i)) {
-		slog.Debug("Swapping!")
+		logger.Debug("Swapping!")
 		h.swap(i, h.parent(i))
 		i = h.parent(i)
 	}
@@ -130,7 +133,7 @@ func (h *Heap[T]) Insert(weight int, value T) {
 }
 
 func (h *Heap[T]) ChangeWeight(weight int, value T) error {
-	slog.Debug("Changing weight:", "weight", weight, "value", value)
+	logger.Debug("Changing weight:", "weight", weight, "value", value)
 	i, ok := h.valueMap[value]
 	if !ok {
 		return fmt.Errorf("Couldn't find value %v in heap", value)
@@ -138,9 +141,15 @@ func (h *Heap[T]) ChangeWeight(weight int, value T) error {
 
 	h.contents[i].weight = weight
 	h.fix(i)
+	h.heapify(h.valueMap[value])
 	return nil
 }
 
+func (h *Heap[T]) Has(value T) bool {
+	_, ok := h.valueMap[value]
+	return ok
+}
+
 func (h *Heap[T]) Extract() (int, T, error) {
 	if h.Size() == 0 {
 		var null T
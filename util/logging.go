@@ -0,0 +1,166 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Each package gets its own named logger so its level can be set on its own. The loggers look up
+// their handler and level on every call, so configuring logging after they are made still works
+type logConfig struct {
+	mu       sync.RWMutex
+	handler  slog.Handler
+	fallback slog.Level
+	levels   map[string]slog.Level
+	names    Set[string]
+}
+
+var logCfg = &logConfig{
+	handler:  slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.Level(-100)}),
+	fallback: slog.LevelInfo,
+	levels:   make(map[string]slog.Level),
+	names:    MakeSet[string](),
+}
+
+func (c *logConfig) level(name string) slog.Level {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if lvl, ok := c.levels[name]; ok {
+		return lvl
+	}
+	return c.fallback
+}
+
+func (c *logConfig) base() slog.Handler {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.handler
+}
+
+// Wraps holds the WithAttrs and WithGroup calls in the order they were made. They are replayed on the base
+// handler for each record, so attrs added before a group stay outside it
+type namedHandler struct {
+	name  string
+	wraps []func(slog.Handler) slog.Handler
+}
+
+func (h *namedHandler) Enabled(ctx context.Context, lvl slog.Level) bool {
+	return lvl >= logCfg.level(h.name)
+}
+
+func (h *namedHandler) Handle(ctx context.Context, rec slog.Record) error {
+	base := logCfg.base().WithAttrs([]slog.Attr{slog.String("logger", h.name)})
+	for _, wrap := range h.wraps {
+		base = wrap(base)
+	}
+	return base.Handle(ctx, rec)
+}
+
+func (h *namedHandler) with(wrap func(slog.Handler) slog.Handler) *namedHandler {
+	return &namedHandler{name: h.name, wraps: append(h.wraps[:len(h.wraps):len(h.wraps)], wrap)}
+}
+
+func (h *namedHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	return h.with(func(base slog.Handler) slog.Handler { return base.WithAttrs(attrs) })
+}
+
+func (h *namedHandler) WithGroup(group string) slog.Handler {
+	if group == "" {
+		return h
+	}
+	return h.with(func(base slog.Handler) slog.Handler { return base.WithGroup(group) })
+}
+
+// Makes a named logger. The name is remembered so ParseLogLevels can reject names nothing logs under
+func Logger(name string) *slog.Logger {
+	logCfg.mu.Lock()
+	logCfg.names.Add(name)
+	logCfg.mu.Unlock()
+	return slog.New(&namedHandler{name: name})
+}
+
+var logger = Logger("util")
+
+// Parses a spec like "debug" or "graph=debug,heap=warn". A bare level sets the level for every
+// logger that isn't named. Names must belong to a logger that has already been made
+func ParseLogLevels(spec string) (fallback *slog.Level, levels map[string]slog.Level, err error) {
+	levels = make(map[string]slog.Level)
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		name, lvlStr, named := strings.Cut(part, "=")
+		if !named {
+			lvlStr = name
+		}
+
+		var lvl slog.Level
+		err = lvl.UnmarshalText([]byte(lvlStr))
+		if err != nil {
+			return nil, nil, fmt.Errorf("Bad log level %q in %q", lvlStr, part)
+		}
+
+		if named {
+			name = strings.TrimSpace(name)
+			if !knownLogger(name) {
+				return nil, nil, fmt.Errorf("Unknown logger %q in %q, expected one of %v", name, part, loggerNames())
+			}
+			levels[name] = lvl
+		} else {
+			fallback = &lvl
+		}
+	}
+	return fallback, levels, nil
+}
+
+func knownLogger(name string) bool {
+	logCfg.mu.RLock()
+	defer logCfg.mu.RUnlock()
+	return logCfg.names.Has(name)
+}
+
+func loggerNames() string {
+	logCfg.mu.RLock()
+	defer logCfg.mu.RUnlock()
+	names := logCfg.names.Items()
+	slices.Sort(names)
+	return strings.Join(names, ", ")
+}
+
+const (
+	LOG_TEXT = "text"
+	LOG_JSON = "json"
+)
+
+func ConfigureLogs(w io.Writer, format string, fallback slog.Level, levels map[string]slog.Level) error {
+	// The named loggers do their own level filtering, so the base handler lets everything through
+	opts := &slog.HandlerOptions{Level: slog.Level(-100)}
+
+	var handler slog.Handler
+	switch format {
+	case LOG_TEXT:
+		handler = slog.NewTextHandler(w, opts)
+	case LOG_JSON:
+		handler = slog.NewJSONHandler(w, opts)
+	default:
+		return fmt.Errorf("Unknown log format %q, expected text or json", format)
+	}
+
+	logCfg.mu.Lock()
+	defer logCfg.mu.Unlock()
+	logCfg.handler = handler
+	logCfg.fallback = fallback
+	logCfg.levels = levels
+	return nil
+}
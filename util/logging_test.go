@@ -0,0 +1,73 @@
+package util_test
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dusktreader/advent-of-code-2024/util"
+)
+
+func TestParseLogLevels(t *testing.T) {
+	util.Logger("graph")
+	util.Logger("heap")
+	fallback, levels, err := util.ParseLogLevels("warn, graph=debug,heap=error")
+	util.Unexpect(t, err)
+
+	if fallback == nil || *fallback != slog.LevelWarn {
+		t.Errorf("Wrong fallback level: %v", fallback)
+	}
+	want := map[string]slog.Level{"graph": slog.LevelDebug, "heap": slog.LevelError}
+	if !reflect.DeepEqual(want, levels) {
+		t.Errorf("Wrong levels: want %v, got %v", want, levels)
+	}
+
+	_, _, err = util.ParseLogLevels("graph=chatty")
+	if err == nil {
+		t.Errorf("Bad level should fail to parse")
+	}
+
+	_, _, err = util.ParseLogLevels("grpah=debug")
+	if err == nil || !strings.Contains(err.Error(), "grpah") {
+		t.Errorf("Unknown logger should fail to parse: %v", err)
+	}
+}
+
+func TestNamedLoggers(t *testing.T) {
+	var buf bytes.Buffer
+	err := util.ConfigureLogs(&buf, util.LOG_JSON, slog.LevelInfo, map[string]slog.Level{"loud": slog.LevelDebug})
+	util.Unexpect(t, err)
+	defer util.ConfigureLogs(os.Stderr, util.LOG_TEXT, slog.LevelInfo, nil)
+
+	util.Logger("loud").Debug("Shown", "k", 1)
+	util.Logger("quiet").Debug("Hidden")
+	util.Logger("quiet").Info("Also shown")
+
+	out := buf.String()
+	if !strings.Contains(out, `"msg":"Shown"`) || !strings.Contains(out, `"logger":"loud"`) {
+		t.Errorf("Debug log from the loud logger is missing: %v", out)
+	}
+	if strings.Contains(out, "Hidden") {
+		t.Errorf("Debug log from the quiet logger should be filtered: %v", out)
+	}
+	if !strings.Contains(out, "Also shown") {
+		t.Errorf("Info log from the quiet logger is missing: %v", out)
+	}
+}
+
+func TestLoggerGroups(t *testing.T) {
+	var buf bytes.Buffer
+	err := util.ConfigureLogs(&buf, util.LOG_JSON, slog.LevelInfo, nil)
+	util.Unexpect(t, err)
+	defer util.ConfigureLogs(os.Stderr, util.LOG_TEXT, slog.LevelInfo, nil)
+
+	util.Logger("grouped").With("outer", 1).WithGroup("g").With("inner", 2).WithGroup("h").Info("Nested", "k", 3)
+
+	want := `"outer":1,"g":{"inner":2,"h":{"k":3}}`
+	if out := buf.String(); !strings.Contains(out, want) {
+		t.Errorf("Attrs should stay in the group they were added in: want %v in %v", want, out)
+	}
+}
@@ -3,7 +3,6 @@ package util
 import (
 	"fmt"
 	"iter"
-	"log/slog"
 	"math"
 	"math/rand"
 	"strings"
@@ -12,7 +11,7 @@ import (
 
 func ReErr(err error, msg string, fmtArgs ...any) error {
 	fmtMsg := fmt.Sprintf(msg, fmtArgs...)
-	return fmt.Errorf("%s: %+v", fmtMsg, err)
+	return fmt.Errorf("%s: %w", fmtMsg, err)
 }
 
 func Unexpect(t *testing.T, err error) {
@@ -542,6 +541,99 @@ func (sm *SetMap[T, U]) Clone() SetMap[T, U] {
 	return nm
 }
 
+// Disjoint sets with path compression and union by rank, so any run of operations takes near linear time
+type UnionFind [T comparable] struct {
+	parent map[T]T
+	rank   map[T]int
+	count  int
+}
+
+func MakeUnionFind[T comparable](items ...T) *UnionFind[T] {
+	uf := UnionFind[T]{
+		parent: make(map[T]T),
+		rank:   make(map[T]int),
+	}
+	uf.Add(items...)
+	return &uf
+}
+
+// Each new item starts out in a set of its own
+func (uf *UnionFind[T]) Add(items ...T) {
+	for _, item := range items {
+		if _, ok := uf.parent[item]; !ok {
+			uf.parent[item] = item
+			uf.count++
+		}
+	}
+}
+
+func (uf *UnionFind[T]) Has(item T) bool {
+	_, ok := uf.parent[item]
+	return ok
+}
+
+// The representative of the set holding item. Items that haven't been seen are added
+func (uf *UnionFind[T]) Find(item T) T {
+	uf.Add(item)
+	root := item
+	for uf.parent[root] != root {
+		root = uf.parent[root]
+	}
+	for item != root {
+		next := uf.parent[item]
+		uf.parent[item] = root
+		item = next
+	}
+	return root
+}
+
+// Joins the sets holding a and b. Returns false if they were already the same set
+func (uf *UnionFind[T]) Union(a T, b T) bool {
+	ra := uf.Find(a)
+	rb := uf.Find(b)
+	if ra == rb {
+		return false
+	}
+
+	if uf.rank[ra] < uf.rank[rb] {
+		ra, rb = rb, ra
+	}
+	uf.parent[rb] = ra
+	if uf.rank[ra] == uf.rank[rb] {
+		uf.rank[ra]++
+	}
+	uf.count--
+	return true
+}
+
+func (uf *UnionFind[T]) Same(a T, b T) bool {
+	return uf.Find(a) == uf.Find(b)
+}
+
+// How many disjoint sets there are
+func (uf *UnionFind[T]) Count() int {
+	return uf.count
+}
+
+func (uf *UnionFind[T]) Sets() []Set[T] {
+	byRoot := make(map[T]Set[T])
+	for item := range uf.parent {
+		root := uf.Find(item)
+		set, ok := byRoot[root]
+		if !ok {
+			set = MakeSet[T]()
+			byRoot[root] = set
+		}
+		set.Add(item)
+	}
+
+	sets := make([]Set[T], 0, len(byRoot))
+	for _, set := range byRoot {
+		sets = append(sets, set)
+	}
+	return sets
+}
+
 type Pair [T any] struct {
 	Left T
 	Right T
@@ -1097,7 +1189,9 @@ func (dag DAG[T]) SortDumb(items []T) []T {
 	itemSet := MakeSet(items...)
 	prunedDag := dag.Prune(itemSet)
 	newItems, err := prunedDag.Sort(items)
-	slog.Error("Should really do something with this:", "err", err)
+	if err != nil {
+		logger.Error("Should really do something with this:", "err", err)
+	}
 	return newItems
 }
 
@@ -149,6 +149,42 @@ func TestSetMap(t *testing.T) {
 	}
 }
 
+func TestUnionFind(t *testing.T) {
+	uf := util.MakeUnionFind(1, 2, 3, 4, 5)
+	if uf.Count() != 5 {
+		t.Fatalf("Expected 5 sets to start, got %v", uf.Count())
+	}
+
+	if !uf.Union(1, 2) || !uf.Union(3, 4) || !uf.Union(2, 4) {
+		t.Fatalf("Unions of separate sets should succeed")
+	}
+	if uf.Union(1, 3) {
+		t.Fatalf("Union of items already in the same set should fail")
+	}
+	if !uf.Same(1, 4) || uf.Same(1, 5) {
+		t.Fatalf("Wrong sets after unions: %v", uf.Sets())
+	}
+
+	uf.Union(6, 5)
+	if uf.Count() != 2 || !uf.Has(6) {
+		t.Fatalf("Expected 2 sets after adding 6 with a union, got %v", uf.Count())
+	}
+
+	want := []util.Set[int]{util.MakeSet(1, 2, 3, 4), util.MakeSet(5, 6)}
+	got := uf.Sets()
+	if len(got) != len(want) {
+		t.Fatalf("Wrong sets: want %v, got %v", want, got)
+	}
+	OUTER: for _, ws := range want {
+		for _, gs := range got {
+			if ws.Eq(gs) {
+				continue OUTER
+			}
+		}
+		t.Errorf("Didn't find expected set %v in %v", ws, got)
+	}
+}
+
 func TestDagBasic(t *testing.T) {
 	dag := util.MakeDag[int]()
 	if dag.Nodes().Size() != 0 {